@@ -0,0 +1,53 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+
+	"github.com/divan/graphx/formats"
+	"github.com/divan/graphx/graph/diff"
+)
+
+func main() {
+	var (
+		oldFile = flag.String("old", "old.json", "File to read the old graph from")
+		newFile = flag.String("new", "network.json", "File to read the new graph from")
+		output  = flag.String("o", "", "Output file for the diff (stdout if empty)")
+	)
+	flag.Parse()
+
+	a, err := formats.FromD3JSON(*oldFile)
+	if err != nil {
+		log.Fatalf("Error reading old graph: %v", err)
+	}
+	b, err := formats.FromD3JSON(*newFile)
+	if err != nil {
+		log.Fatalf("Error reading new graph: %v", err)
+	}
+
+	d, err := diff.Compare(a, b)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	out := os.Stdout
+	if *output != "" {
+		fd, err := os.Create(*output)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer fd.Close()
+		out = fd
+	}
+
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(d); err != nil {
+		log.Fatal(err)
+	}
+	if d.Empty() {
+		log.Println("Graphs are equal")
+	}
+}
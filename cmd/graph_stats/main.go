@@ -0,0 +1,47 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+
+	"github.com/divan/graphx/formats"
+	"github.com/divan/graphx/graph/stats"
+)
+
+func main() {
+	var (
+		input   = flag.String("i", "network.json", "File to read network graph from")
+		output  = flag.String("o", "", "Output file (stdout if empty)")
+		exact   = flag.Int("exact", stats.DefaultExactLimit, "Maximum number of nodes for exact diameter computation")
+		samples = flag.Int("samples", stats.DefaultSamples, "Number of samples for diameter estimation of larger graphs")
+	)
+	flag.Parse()
+
+	g, err := formats.FromD3JSON(*input)
+	if err != nil {
+		log.Fatalf("Error reading network graph: %v", err)
+	}
+
+	report := stats.Analyze(g, &stats.Options{
+		ExactLimit: *exact,
+		Samples:    *samples,
+	})
+
+	out := os.Stdout
+	if *output != "" {
+		fd, err := os.Create(*output)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer fd.Close()
+		out = fd
+	}
+
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(report); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"flag"
 	"fmt"
+	"io"
 	"log"
 	"os"
 
@@ -57,40 +58,20 @@ func main() {
 	case "json":
 		err = formats.ToPositionsJSONFile(positions, *output)
 	case "preset":
-		var res struct {
-			Description string             `json:"description"`
-			Nodes       []*graph.BasicNode `json:"nodes"`
-			Links       []*struct {
-				Source string `json:"source"`
-				Target string `json:"target"`
-			} `json:"links"`
-			Positions []*layout.Position `json:"positions"`
-		}
-
-		// read input file again
-		fd, err := os.Open(*input)
-		if err != nil {
-			log.Fatal(err)
-		}
-		defer fd.Close()
-
-		err = json.NewDecoder(fd).Decode(&res)
+		description, err := readDescription(*input)
 		if err != nil {
 			log.Fatal(err)
 		}
 
-		// add newly calculated positions
-		res.Positions = positions
-
 		// write preset to the output file
 		fdOut, err := os.Create(*output)
 		if err != nil {
 			log.Fatal(err)
 		}
 		defer fdOut.Close()
-		enc := json.NewEncoder(fdOut)
-		enc.SetIndent("", "  ")
-		err = enc.Encode(res)
+		if err := writePreset(fdOut, g, description, positions); err != nil {
+			log.Fatal(err)
+		}
 	default:
 		err = fmt.Errorf("Unknown export format '%s'", *t)
 	}
@@ -100,3 +81,44 @@ func main() {
 
 	log.Printf("Written output to %s", *output)
 }
+
+// preset represents graph along with calculated positions.
+type preset struct {
+	Description string             `json:"description"`
+	Directed    bool               `json:"directed,omitempty"`
+	Nodes       []graph.Node       `json:"nodes"`
+	Links       []*graph.Link      `json:"links"`
+	Positions   []*layout.Position `json:"positions"`
+}
+
+// readDescription reads description from the input file, as it's not
+// a part of the graph.
+func readDescription(file string) (string, error) {
+	fd, err := os.Open(file)
+	if err != nil {
+		return "", err
+	}
+	defer fd.Close()
+
+	var in struct {
+		Description string `json:"description"`
+	}
+	err = json.NewDecoder(fd).Decode(&in)
+	return in.Description, err
+}
+
+// writePreset writes graph with positions as a preset. Nodes and links keep
+// all their attributes from the input file.
+func writePreset(w io.Writer, g *graph.Graph, description string, positions []*layout.Position) error {
+	res := preset{
+		Description: description,
+		Directed:    g.Directed(),
+		Nodes:       g.Nodes(),
+		Links:       g.Links(),
+		Positions:   positions,
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(res)
+}
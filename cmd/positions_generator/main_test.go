@@ -0,0 +1,58 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/divan/graphx/formats"
+	"github.com/divan/graphx/layout"
+)
+
+func TestPreset(t *testing.T) {
+	input := filepath.Join(t.TempDir(), "network.json")
+	data := `{
+		"description": "test network",
+		"nodes": [{"id": "a", "role": "db"}, {"id": "b"}],
+		"links": [{"source": "a", "target": "b"}]
+	}`
+	if err := os.WriteFile(input, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	g, err := formats.FromD3JSON(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	description, err := readDescription(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	positions := []*layout.Position{{X: 1}, {Y: 2}}
+
+	var buf bytes.Buffer
+	if err := writePreset(&buf, g, description, positions); err != nil {
+		t.Fatal(err)
+	}
+
+	var res struct {
+		Description string                   `json:"description"`
+		Nodes       []map[string]interface{} `json:"nodes"`
+		Links       []map[string]interface{} `json:"links"`
+		Positions   []*layout.Position       `json:"positions"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &res); err != nil {
+		t.Fatal(err)
+	}
+	if res.Description != "test network" {
+		t.Fatalf("Expected description %q, but got %q", "test network", res.Description)
+	}
+	if len(res.Nodes) != 2 || res.Nodes[0]["role"] != "db" {
+		t.Fatalf("Expected node attributes to be preserved, but got %v", res.Nodes)
+	}
+	if len(res.Links) != 1 || len(res.Positions) != 2 || res.Positions[1].Y != 2 {
+		t.Fatalf("Unexpected preset: %+v", res)
+	}
+}
@@ -47,24 +47,17 @@ func NewD3JSON(w io.Writer, indented bool) *D3JSON {
 }
 
 // ExportGraph converts graph into D3 JSON format. Implements GraphExporter interface.
+// Links are written with their weights and attributes.
 func (d *D3JSON) ExportGraph(g *graph.Graph) error {
-	type link struct {
-		Source string `json:"source"`
-		Target string `json:"target"`
-	}
 	var data struct {
-		Nodes []graph.Node `json:"nodes"`
-		Links []*link      `json:"links"`
+		Directed bool          `json:"directed,omitempty"`
+		Nodes    []graph.Node  `json:"nodes"`
+		Links    []*graph.Link `json:"links"`
 	}
 
+	data.Directed = g.Directed()
 	data.Nodes = g.Nodes()
-	data.Links = make([]*link, g.NumLinks())
-	for i, l := range g.Links() {
-		data.Links[i] = &link{
-			Source: l.From(),
-			Target: l.To(),
-		}
-	}
+	data.Links = g.Links()
 
 	enc := json.NewEncoder(d.writer)
 	if d.indented {
@@ -76,7 +69,10 @@ func (d *D3JSON) ExportGraph(g *graph.Graph) error {
 
 // FromD3JSON creates a graph from the given JSON file.
 // It recognizes simple JSON structure suitable for D3 examples,
-// basically just `id`, `group` and `weight` fields for nodes.
+// basically just `id`, `group` and `weight` fields for nodes and
+// `source`, `target` and `weight` fields for links. All other node
+// and link fields are stored as attributes.
+// If top-level `directed` field is true, directed graph is created.
 func FromD3JSON(file string) (*graph.Graph, error) {
 	fd, err := os.Open(file)
 	if err != nil {
@@ -89,13 +85,35 @@ func FromD3JSON(file string) (*graph.Graph, error) {
 
 // FromD3JSONReader creates a graph from the given JSON file.
 func FromD3JSONReader(r io.Reader) (*graph.Graph, error) {
+	return fromD3JSONReader(r, func(node *graph.BasicNode) graph.Node { return node })
+}
+
+// FromD3JSONTyped creates a graph with nodes of type N from the given JSON file.
+// Nodes are decoded directly into N, so it should implement json.Unmarshaler or
+// have appropriate struct tags. Links are handled the same way as in FromD3JSON.
+func FromD3JSONTyped[N graph.Node](file string) (*graph.TypedGraph[N], error) {
+	fd, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer fd.Close() //nolint: errcheck
+
+	return FromD3JSONReaderTyped[N](fd)
+}
+
+// FromD3JSONReaderTyped creates a graph with nodes of type N from the given JSON file.
+func FromD3JSONReaderTyped[N graph.Node](r io.Reader) (*graph.TypedGraph[N], error) {
+	return fromD3JSONReader(r, func(node N) N { return node })
+}
+
+// fromD3JSONReader decodes nodes of type T from JSON and creates graph
+// with nodes of type N, using convert function.
+func fromD3JSONReader[T, N graph.Node](r io.Reader, convert func(T) N) (*graph.TypedGraph[N], error) {
 	// decode into temporary struct to process
 	var res struct {
-		Nodes []*graph.BasicNode `json:"nodes"`
-		Links []*struct {
-			Source string `json:"source"`
-			Target string `json:"target"`
-		} `json:"links"`
+		Directed bool          `json:"directed"`
+		Nodes    []T           `json:"nodes"`
+		Links    []*graph.Link `json:"links"`
 	}
 	err := json.NewDecoder(r).Decode(&res)
 	if err != nil {
@@ -107,17 +125,18 @@ func FromD3JSONReader(r io.Reader) (*graph.Graph, error) {
 	}
 
 	// convert links IDs into indices
-	g := graph.NewGraphMN(len(res.Nodes), len(res.Links))
+	g := graph.NewTypedGraphMN[N](len(res.Nodes), len(res.Links))
+	if res.Directed {
+		g = graph.NewDirectedTypedGraphMN[N](len(res.Nodes), len(res.Links))
+	}
 
 	for _, node := range res.Nodes {
-		g.AddNode(node)
+		g.AddNode(convert(node))
 	}
 
-	for _, link := range res.Links {
-		err := g.AddLink(link.Source, link.Target)
-		if err != nil {
-			return nil, err
-		}
+	err = g.AddLinks(res.Links...)
+	if err != nil {
+		return nil, err
 	}
 
 	return g, err
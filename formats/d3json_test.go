@@ -6,6 +6,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/divan/graphx/graph"
@@ -125,3 +126,137 @@ func readTestData() ([]string, error) {
 	}
 	return ret, nil
 }
+
+func TestD3JSONDirected(t *testing.T) {
+	g := graph.NewDirectedGraph()
+	g.AddNode(node(1))
+	g.AddNode(node(2))
+	g.AddLink("1", "2")
+
+	var buf bytes.Buffer
+	err := NewD3JSON(&buf, false).ExportGraph(g)
+	if err != nil {
+		t.Fatalf("Exporting graph to D3 JSON failed: %v", err)
+	}
+
+	g1, err := FromD3JSONReader(&buf)
+	if err != nil {
+		t.Fatalf("Importing graph from D3 JSON failed: %v", err)
+	}
+	if !g1.Directed() {
+		t.Fatalf("Expected imported graph to be directed")
+	}
+	if !g1.LinkExists("1", "2") || g1.LinkExists("2", "1") {
+		t.Fatalf("Expected imported link to respect direction")
+	}
+}
+
+func TestD3JSONLinkAttributes(t *testing.T) {
+	buf := bytes.NewBufferString(`{
+		"nodes": [ {"id": "A"}, {"id": "B"}, {"id": "C"} ],
+		"links": [ {"source": "A", "target": "B", "weight": 2.5, "latency": 10, "label": "uplink"}, {"source": "B", "target": "C"}]
+	}`)
+	g, err := FromD3JSONReader(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	check := func(g *graph.Graph) {
+		links := g.Links()
+		if links[0].Weight() != 2.5 || links[1].Weight() != graph.DefaultLinkWeight {
+			t.Fatalf("Unexpected link weights: %v, %v", links[0].Weight(), links[1].Weight())
+		}
+		if v, ok := links[0].Attr("label"); !ok || v != "uplink" {
+			t.Fatalf("Expected label attribute to be 'uplink', but got %v", v)
+		}
+		if v, ok := links[0].Attr("latency"); !ok || v != 10.0 {
+			t.Fatalf("Expected latency attribute to be 10, but got %v", v)
+		}
+		if len(links[1].Attrs()) != 0 {
+			t.Fatalf("Expected no attributes, but got %v", links[1].Attrs())
+		}
+	}
+	check(g)
+
+	var out bytes.Buffer
+	err = NewD3JSON(&out, false).ExportGraph(g)
+	if err != nil {
+		t.Fatalf("Exporting graph to D3 JSON failed: %v", err)
+	}
+	g1, err := FromD3JSONReader(&out)
+	if err != nil {
+		t.Fatalf("Importing graph from D3 JSON failed: %v", err)
+	}
+	check(g1)
+}
+
+func TestD3JSONNodeAttributes(t *testing.T) {
+	input := `{"nodes":[{"id":"A","group":2,"hostname":"alpha","meta":{"role":"db","version":3}},{"id":"B"}],"links":[{"source":"A","target":"B"}]}`
+	g, err := FromD3JSONReader(bytes.NewBufferString(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	node, err := g.Node("A")
+	if err != nil {
+		t.Fatal(err)
+	}
+	n := node.(*graph.BasicNode)
+	if n.Group() != 2 {
+		t.Fatalf("Expected group to be %d, but got %d", 2, n.Group())
+	}
+	if v, ok := n.Attr("hostname"); !ok || v != "alpha" {
+		t.Fatalf("Expected hostname attribute to be 'alpha', but got %v", v)
+	}
+	if _, ok := n.Attr("id"); ok {
+		t.Fatalf("Expected known fields not to be stored as attributes")
+	}
+
+	var out bytes.Buffer
+	err = NewD3JSON(&out, false).ExportGraph(g)
+	if err != nil {
+		t.Fatalf("Exporting graph to D3 JSON failed: %v", err)
+	}
+	got := strings.TrimSpace(out.String())
+	if got != input {
+		t.Fatalf("Expected round-trip to preserve JSON, but got:\n%s\nexpected:\n%s", got, input)
+	}
+}
+
+type hostNode struct {
+	Hostname string `json:"id"`
+	Role     string `json:"role"`
+}
+
+func (h *hostNode) ID() string { return h.Hostname }
+
+func TestD3JSONTyped(t *testing.T) {
+	buf := bytes.NewBufferString(`{
+		"nodes": [ {"id": "alpha", "role": "db"}, {"id": "beta", "role": "web"} ],
+		"links": [ {"source": "alpha", "target": "beta"} ]
+	}`)
+	g, err := FromD3JSONReaderTyped[*hostNode](buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	node, err := g.Node("beta")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if node.Role != "web" {
+		t.Fatalf("Expected role to be '%s', but got '%s'", "web", node.Role)
+	}
+	if !g.LinkExists("beta", "alpha") {
+		t.Fatalf("Expected link to exist")
+	}
+
+	var out bytes.Buffer
+	err = NewD3JSON(&out, false).ExportGraph(g.Untyped())
+	if err != nil {
+		t.Fatalf("Exporting graph to D3 JSON failed: %v", err)
+	}
+	if !strings.Contains(out.String(), `"role":"db"`) {
+		t.Fatalf("Expected exported JSON to contain node fields, but got: %s", out.String())
+	}
+}
@@ -1,6 +1,7 @@
 package formats
 
 import (
+	"bufio"
 	"encoding/binary"
 	"encoding/json"
 	"fmt"
@@ -45,12 +46,18 @@ func NewNgraphBinary(dir string) (*NgraphBinary, error) {
 // an output directory.
 // Implements GraphExporter.
 func (n *NgraphBinary) ExportGraph(g *graph.Graph) error {
-	err := n.writeLinksBin(g)
+	return n.ExportCSR(g.Freeze())
+}
+
+// ExportCSR exports graph snapshot into binary files (links.bin and labels.json) in
+// an output directory. It's more efficient for large graphs, as it doesn't require
+// Graph to be kept in memory.
+func (n *NgraphBinary) ExportCSR(c *graph.CSR) error {
+	err := n.writeLinksBin(c)
 	if err != nil {
 		return err
 	}
-
-	return n.writeLabels(g)
+	return n.writeLabels(c)
 }
 
 // ExportLayout writes position data into 'positions.bin' file in the
@@ -66,7 +73,7 @@ func (n *NgraphBinary) ExportLayout(l layout.Layout) error {
 // writeLinksBin writes links information into `links.bin` file in the
 // following way: Sidx,L1idx,L2idx,S2idx,L1idx... where SNidx - is the
 // start node index, and LNidx - is the other link end node index.
-func (n *NgraphBinary) writeLinksBin(g *graph.Graph) error {
+func (n *NgraphBinary) writeLinksBin(c *graph.CSR) error {
 	file := filepath.Join(n.Dir, "links.bin")
 	fd, err := os.Create(file)
 	if err != nil {
@@ -74,31 +81,42 @@ func (n *NgraphBinary) writeLinksBin(g *graph.Graph) error {
 	}
 	defer fd.Close()
 
-	iw := newInt32LEWriter(fd)
-	_ = iw
-	for _, node := range g.Nodes() {
-		if !g.NodeHasLinks(node.ID()) {
-			continue
-		}
+	w := bufio.NewWriter(fd)
+	err = ToLinksNGraph(c, w)
+	if err != nil {
+		return err
+	}
+	return w.Flush()
+}
 
-		/* TODO: implement links cache
-		iw.Write(int32(-(i + 1)))
-		for _, link := range data.Links() {
-			if link.From() == i {
-				iw.Write(int32(link.To() + 1))
+// ToLinksNGraph writes graph links to the io.Writer in the NGraph binary format.
+// Start node index is written as negative 1-based number, followed by 1-based
+// indices of the other link ends. Links of undirected graphs are written only
+// once, from the node with lower index.
+func ToLinksNGraph(c *graph.CSR, w io.Writer) error {
+	iw := newInt32LEWriter(w)
+	for i := 0; i < c.NumNodes(); i++ {
+		started := false
+		for _, j := range c.Neighbors(i) {
+			if !c.Directed() && int(j) < i {
+				continue
 			}
+			if !started {
+				iw.Write(int32(-(i + 1)))
+				started = true
+			}
+			iw.Write(j + 1)
 		}
 		if iw.err != nil {
-			return err
+			return fmt.Errorf("write Int32LE: %v", iw.err)
 		}
-		*/
 	}
 	return nil
 }
 
 // writeLabels writes node ids (labels) information into `labels.json` file
 // as an array of strings.
-func (n *NgraphBinary) writeLabels(g *graph.Graph) error {
+func (n *NgraphBinary) writeLabels(c *graph.CSR) error {
 	file := filepath.Join(n.Dir, "labels.json")
 	fd, err := os.Create(file)
 	if err != nil {
@@ -106,11 +124,7 @@ func (n *NgraphBinary) writeLabels(g *graph.Graph) error {
 	}
 	defer fd.Close()
 
-	var labels []string
-	for i := range g.Nodes() {
-		labels = append(labels, g.Nodes()[i].ID())
-	}
-	return json.NewEncoder(fd).Encode(labels)
+	return json.NewEncoder(fd).Encode(c.IDs())
 }
 
 // int32LEWriter implements binary writer for signed little-endian 32bit integers.
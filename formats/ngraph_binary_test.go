@@ -0,0 +1,48 @@
+package formats
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+)
+
+func TestLinksNGraph(t *testing.T) {
+	g := testGraph() // 1-2, 2-3
+	g.AddLink("3", "1")
+
+	var buf bytes.Buffer
+	err := ToLinksNGraph(g.Freeze(), &buf)
+	if err != nil {
+		t.Fatalf("Writing links failed: %v", err)
+	}
+
+	got := make([]int32, buf.Len()/4)
+	err = binary.Read(&buf, binary.LittleEndian, got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []int32{-1, 2, 3, -2, 3}
+	if len(got) != len(expected) {
+		t.Fatalf("Expected %v, but got %v", expected, got)
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Fatalf("Expected %v, but got %v", expected, got)
+		}
+	}
+}
+
+func BenchmarkExportNGraphLinks(b *testing.B) {
+	g, err := FromD3JSON("testdata/net10k.json")
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	c := g.Freeze()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var buf bytes.Buffer
+		ToLinksNGraph(c, &buf)
+	}
+}
@@ -12,6 +12,7 @@ type WattsStrogatzGenerator struct {
 	nodes              int // number of nodes
 	conns              int // number of neigbours
 	rewritePropability float64
+	rnd                *rand.Rand // source of randomness, global one if nil
 }
 
 // NewWattsStrogatzGenerator creates new Watts-Strogatz generator for N nodes graph.
@@ -26,10 +27,22 @@ func NewWattsStrogatzGenerator(n, conns int) *WattsStrogatzGenerator {
 	}
 }
 
+// WithRand sets source of randomness for rewiring, so generated graphs
+// are reproducible.
+func (l *WattsStrogatzGenerator) WithRand(r *rand.Rand) *WattsStrogatzGenerator {
+	l.rnd = r
+	return l
+}
+
 // Generate generates the data for graph. Implements Generator interface.
 func (l *WattsStrogatzGenerator) Generate() *graph.Graph {
 	g := graph.NewGraph()
 
+	randFloat, randIntn := rand.Float64, rand.Intn
+	if l.rnd != nil {
+		randFloat, randIntn = l.rnd.Float64, l.rnd.Intn
+	}
+
 	for i := 0; i < l.nodes; i++ {
 		addNode(g, i)
 
@@ -48,40 +61,27 @@ func (l *WattsStrogatzGenerator) Generate() *graph.Graph {
 	neigbors = int(math.Floor(float64(l.conns/2 + 1)))
 	for j := 1; j < neigbors; j++ {
 		for i := 0; i < l.nodes; i++ {
-			if rand.Float64() > l.rewritePropability {
+			if randFloat() > l.rewritePropability {
 				continue
 			}
 
 			from := i
 			to := int(math.Mod(float64(i+j), float64(l.nodes)))
-			newTo := rand.Intn(l.nodes)
+			newTo := randIntn(l.nodes)
 
-			// TODO: switch to link indexes
 			needsRewire := (newTo == i) || g.LinkExists(id(from), id(newTo))
 			if needsRewire && (g.NodeLinks(id(from)) == l.nodes-1) {
 				continue
 			}
 
 			for needsRewire {
-				newTo = rand.Intn(l.nodes)
+				newTo = randIntn(l.nodes)
 				needsRewire = (newTo == i) || g.LinkExists(id(from), id(newTo))
 			}
 
-			rewireLink(g, id(from), id(to), id(newTo))
+			g.RewireLink(id(from), id(to), id(newTo))
 		}
 	}
 
 	return g
 }
-
-// TODO: move it into graph package
-func rewireLink(g *graph.Graph, from, to, newTo string) {
-	links := g.Links()
-	for i := range links {
-		if links[i].From() == from && links[i].To() == to {
-			links[i].Rewire(links[i].From(), newTo)
-		} else if links[i].To() == from && links[i].From() == to {
-			links[i].Rewire(newTo, links[i].To())
-		}
-	}
-}
@@ -15,6 +15,12 @@ func addLink(g *graph.Graph, i, j int) {
 	g.AddLink(id(i), id(j))
 }
 
+// linkExists checks if nodes with indices i and j are linked.
+func linkExists(g *graph.Graph, i, j int) bool {
+	_, err := g.LinkByIndices(i, j)
+	return err == nil
+}
+
 func id(i int) string {
 	return fmt.Sprintf("%d", i)
 }
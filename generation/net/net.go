@@ -107,15 +107,14 @@ func (d *NetGenerator) conns() int {
 // For now it uses uniform distribution and retries two times
 // to minimize the probability of choosing the existing link
 // (it doesn't guarantee, but it's cheap).
-// TODO: make it more efficient and faster
 func nextIdx(g *graph.Graph, i, start, hosts int) (int, error) {
 	// use uniform distribution for now
 	idx := start + rand.Intn(hosts-start-1)
-	if idx == i || g.LinkExists(id(idx), id(i)) {
+	if idx == i || linkExists(g, idx, i) {
 		idx = start + rand.Intn(hosts-start-1)
-		if idx == i || g.LinkExists(id(idx), id(i)) {
+		if idx == i || linkExists(g, idx, i) {
 			idx = start + rand.Intn(hosts-start-1)
-			if idx == i || g.LinkExists(id(idx), id(i)) {
+			if idx == i || linkExists(g, idx, i) {
 				return 0, errors.New("too many colissions")
 			}
 		}
@@ -0,0 +1,177 @@
+package graph
+
+import "fmt"
+
+// edge is a key for looking up links by their endpoints indices.
+type edge struct {
+	from, to int
+}
+
+// edgeRef holds index of the first link between the same pair of nodes,
+// and the total number of such links.
+type edgeRef struct {
+	idx   int
+	count int
+}
+
+// edgeKey returns key for the link between nodes with from and to indices.
+// For undirected graphs the order of endpoints doesn't matter.
+func (g *TypedGraph[N]) edgeKey(from, to int) edge {
+	if !g.directed && from > to {
+		from, to = to, from
+	}
+	return edge{from: from, to: to}
+}
+
+// indexLink adds link with the given index into the adjacency structures.
+func (g *TypedGraph[N]) indexLink(link *Link, idx int) {
+	key := g.edgeKey(link.fromIdx, link.toIdx)
+	if ref, ok := g.edges[key]; ok {
+		ref.count++
+		g.edges[key] = ref
+		return
+	}
+
+	g.edges[key] = edgeRef{idx: idx, count: 1}
+	g.adj[link.fromIdx] = append(g.adj[link.fromIdx], link.toIdx)
+	if g.directed {
+		g.inAdj[link.toIdx] = append(g.inAdj[link.toIdx], link.fromIdx)
+	} else if link.fromIdx != link.toIdx {
+		g.adj[link.toIdx] = append(g.adj[link.toIdx], link.fromIdx)
+	}
+}
+
+// unindexLink removes link with the given index from the adjacency structures.
+func (g *TypedGraph[N]) unindexLink(link *Link, idx int) {
+	key := g.edgeKey(link.fromIdx, link.toIdx)
+	ref, ok := g.edges[key]
+	if !ok {
+		return
+	}
+
+	ref.count--
+	if ref.count == 0 {
+		delete(g.edges, key)
+		g.adj[link.fromIdx] = removeIdx(g.adj[link.fromIdx], link.toIdx)
+		if g.directed {
+			g.inAdj[link.toIdx] = removeIdx(g.inAdj[link.toIdx], link.fromIdx)
+		} else if link.fromIdx != link.toIdx {
+			g.adj[link.toIdx] = removeIdx(g.adj[link.toIdx], link.fromIdx)
+		}
+		return
+	}
+
+	// there are duplicate links, so point to the next one
+	if ref.idx == idx {
+		for i, l := range g.links {
+			if i != idx && g.edgeKey(l.fromIdx, l.toIdx) == key {
+				ref.idx = i
+				break
+			}
+		}
+	}
+	g.edges[key] = ref
+}
+
+// removeIdx removes value v from the slice, preserving the order.
+func removeIdx(s []int, v int) []int {
+	for i := range s {
+		if s[i] == v {
+			return append(s[:i], s[i+1:]...)
+		}
+	}
+	return s
+}
+
+// NeighborIndices returns indices of the nodes linked with the node with given index,
+// in order of links addition. For directed graphs only outgoing links are considered.
+// Returned slice is shared with the graph and must not be modified.
+func (g *TypedGraph[N]) NeighborIndices(idx int) []int {
+	if idx < 0 || idx > len(g.adj)-1 {
+		return nil
+	}
+	return g.adj[idx]
+}
+
+// InNeighborIndices returns indices of the nodes having links to the node
+// with given index. For undirected graphs it's the same as NeighborIndices.
+// Returned slice is shared with the graph and must not be modified.
+func (g *TypedGraph[N]) InNeighborIndices(idx int) []int {
+	if !g.directed {
+		return g.NeighborIndices(idx)
+	}
+	if idx < 0 || idx > len(g.inAdj)-1 {
+		return nil
+	}
+	return g.inAdj[idx]
+}
+
+// Neighbors returns IDs of the nodes linked with the node with given ID.
+// For directed graphs only outgoing links are considered.
+func (g *TypedGraph[N]) Neighbors(id string) ([]string, error) {
+	idx, err := g.NodeByID(id)
+	if err != nil {
+		return nil, err
+	}
+	return g.nodeIDs(g.NeighborIndices(idx)), nil
+}
+
+// InNeighbors returns IDs of the nodes having links to the node with given ID.
+// For undirected graphs it's the same as Neighbors.
+func (g *TypedGraph[N]) InNeighbors(id string) ([]string, error) {
+	idx, err := g.NodeByID(id)
+	if err != nil {
+		return nil, err
+	}
+	return g.nodeIDs(g.InNeighborIndices(idx)), nil
+}
+
+// nodeIDs converts node indices into IDs.
+func (g *TypedGraph[N]) nodeIDs(indices []int) []string {
+	ret := make([]string, len(indices))
+	for i, idx := range indices {
+		ret[i] = g.nodes[idx].ID()
+	}
+	return ret
+}
+
+// RewireLink changes the 'to' end of the link between from and to nodes
+// to the newTo node, keeping the link index.
+func (g *TypedGraph[N]) RewireLink(from, to, newTo string) error {
+	idx, err := g.LinkIndex(from, to)
+	if err != nil {
+		return err
+	}
+	newToIdx, err := g.NodeByID(newTo)
+	if err != nil {
+		return err
+	}
+	fromIdx, err := g.NodeByID(from)
+	if err != nil {
+		return err
+	}
+
+	link := g.links[idx]
+	g.unindexLink(link, idx)
+	g.uncacheLink(link.from, link.to)
+
+	link.from, link.fromIdx = from, fromIdx
+	link.to, link.toIdx = newTo, newToIdx
+
+	g.cacheLink(link.from, link.to)
+	g.indexLink(link, idx)
+	if ref := g.edges[g.edgeKey(link.fromIdx, link.toIdx)]; ref.idx > idx {
+		ref.idx = idx // keep pointing to the first link
+		g.edges[g.edgeKey(link.fromIdx, link.toIdx)] = ref
+	}
+	return nil
+}
+
+// linkIdx returns index of the first link between nodes with given indices.
+func (g *TypedGraph[N]) linkIdx(from, to int) (int, error) {
+	ref, ok := g.edges[g.edgeKey(from, to)]
+	if !ok {
+		return 0, fmt.Errorf("link %d->%d not found", from, to)
+	}
+	return ref.idx, nil
+}
@@ -0,0 +1,87 @@
+package graph
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+)
+
+// Attrs represents arbitrary key/value attributes of graph elements.
+type Attrs map[string]interface{}
+
+// Copy returns shallow copy of attributes. Copy of nil is nil.
+func (a Attrs) Copy() Attrs {
+	if a == nil {
+		return nil
+	}
+	ret := make(Attrs, len(a))
+	for k, v := range a {
+		ret[k] = v
+	}
+	return ret
+}
+
+// marshalWithAttrs encodes v as a JSON object and appends attributes
+// to it as top-level fields, sorted by key. Attributes never override
+// fields of v.
+func marshalWithAttrs(v interface{}, attrs Attrs, known map[string]bool) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil || len(attrs) == 0 {
+		return data, err
+	}
+
+	keys := make([]string, 0, len(attrs))
+	for key := range attrs {
+		if known[key] {
+			continue
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var buf bytes.Buffer
+	buf.Write(data[:len(data)-1]) // strip closing brace
+	for i, key := range keys {
+		if i > 0 || len(data) > 2 {
+			buf.WriteByte(',')
+		}
+		k, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		val, err := json.Marshal(attrs[key])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(k)
+		buf.WriteByte(':')
+		buf.Write(val)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// unmarshalAttrs decodes all JSON object fields, except known ones,
+// into attributes. It returns nil if there are no unknown fields.
+func unmarshalAttrs(data []byte, known map[string]bool) (Attrs, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	var attrs Attrs
+	for key, raw := range fields {
+		if known[key] {
+			continue
+		}
+		var v interface{}
+		if err := json.Unmarshal(raw, &v); err != nil {
+			return nil, err
+		}
+		if attrs == nil {
+			attrs = make(Attrs)
+		}
+		attrs[key] = v
+	}
+	return attrs, nil
+}
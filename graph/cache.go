@@ -1,15 +1,35 @@
 package graph
 
-func (g *Graph) ResetCache() {
+func (g *TypedGraph[N]) ResetCache() {
 	g.nodeLinks = make(map[string]int)
+	g.nodeOutLinks = make(map[string]int)
 	g.nodeIdxByID = make(map[string]int)
+	g.adj = nil
+	g.inAdj = nil
+	g.edges = make(map[edge]edgeRef)
 }
 
-func (g *Graph) cacheNode(node Node, idx int) {
+func (g *TypedGraph[N]) cacheNode(node N, idx int) {
 	g.nodeIdxByID[node.ID()] = idx
 }
 
-func (g *Graph) cacheLink(from, to string) {
+func (g *TypedGraph[N]) cacheLink(from, to string) {
 	g.nodeLinks[from]++
 	g.nodeLinks[to]++
+	g.nodeOutLinks[from]++
+}
+
+func (g *TypedGraph[N]) uncacheLink(from, to string) {
+	g.nodeLinks[from]--
+	g.nodeLinks[to]--
+	g.nodeOutLinks[from]--
+	if g.nodeOutLinks[from] == 0 {
+		delete(g.nodeOutLinks, from)
+	}
+	if g.nodeLinks[from] == 0 {
+		delete(g.nodeLinks, from)
+	}
+	if g.nodeLinks[to] == 0 {
+		delete(g.nodeLinks, to)
+	}
 }
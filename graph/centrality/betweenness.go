@@ -0,0 +1,96 @@
+package centrality
+
+import "github.com/divan/graphx/graph"
+
+// Betweenness returns betweenness centrality: fraction of shortest paths between
+// all pairs of nodes which pass through the node. It uses Brandes algorithm over
+// unweighted shortest paths, and values are normalized to [0, 1] range.
+//
+// For large graphs set Options.Samples to use only given number of random source
+// nodes, which gives unbiased estimation in O(Samples*(N+M)) time.
+func Betweenness(g *graph.Graph, opts *Options) map[string]float64 {
+	o := opts.withDefaults()
+	c := g.Freeze()
+	n := c.NumNodes()
+
+	sources := make([]int, n)
+	for i := range sources {
+		sources[i] = i
+	}
+	if o.Samples > 0 && o.Samples < n {
+		sources = o.Rand.Perm(n)[:o.Samples]
+	}
+
+	cb := make([]float64, n)
+	delta := make([]float64, n)
+	s := newSSSP(n)
+	for _, src := range sources {
+		s.run(c, src)
+
+		for i := range delta {
+			delta[i] = 0
+		}
+		// accumulate dependencies in order of non-increasing distance
+		for i := len(s.order) - 1; i >= 0; i-- {
+			w := s.order[i]
+			for _, v := range c.InNeighbors(int(w)) {
+				if s.dist[v] == s.dist[w]-1 {
+					delta[v] += s.sigma[v] / s.sigma[w] * (1 + delta[w])
+				}
+			}
+			if int(w) != src {
+				cb[w] += delta[w]
+			}
+		}
+	}
+
+	// normalize
+	if n > 2 {
+		scale := 1 / float64((n-1)*(n-2))
+		if len(sources) < n {
+			scale *= float64(n) / float64(len(sources))
+		}
+		for i := range cb {
+			cb[i] *= scale
+		}
+	}
+	return toMap(c, cb)
+}
+
+// sssp holds reusable buffers for single-source shortest paths counting.
+type sssp struct {
+	dist  []int32
+	sigma []float64 // number of shortest paths
+	order []int32   // nodes in order of non-decreasing distance
+}
+
+func newSSSP(n int) *sssp {
+	return &sssp{
+		dist:  make([]int32, n),
+		sigma: make([]float64, n),
+		order: make([]int32, 0, n),
+	}
+}
+
+// run calculates hop distances and number of shortest paths from src.
+func (s *sssp) run(c *graph.CSR, src int) {
+	for i := range s.dist {
+		s.dist[i] = -1
+		s.sigma[i] = 0
+	}
+	s.dist[src] = 0
+	s.sigma[src] = 1
+	s.order = append(s.order[:0], int32(src))
+	for head := 0; head < len(s.order); head++ {
+		u := s.order[head]
+		for _, v := range c.Neighbors(int(u)) {
+			if s.dist[v] == -1 {
+				s.dist[v] = s.dist[u] + 1
+				s.order = append(s.order, v)
+			}
+			if s.dist[v] == s.dist[u]+1 {
+				s.sigma[v] += s.sigma[u]
+			}
+		}
+	}
+}
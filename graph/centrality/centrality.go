@@ -0,0 +1,134 @@
+// Package centrality implements node centrality measures: degree, closeness,
+// betweenness, eigenvector centrality and PageRank. All measures return
+// per-node scores keyed by node ID.
+package centrality
+
+import (
+	"errors"
+	"math/rand"
+
+	"github.com/divan/graphx/graph"
+)
+
+// ErrNotConverged is returned when iterative method doesn't converge within
+// the maximum number of iterations. Scores are still returned in that case.
+var ErrNotConverged = errors.New("not converged")
+
+// Default values for Options.
+const (
+	DefaultMaxIter   = 100
+	DefaultTolerance = 1e-6
+	DefaultDamping   = 0.85
+)
+
+// Options specifies parameters for centrality measures. Nil options or
+// zero values mean defaults.
+type Options struct {
+	// Samples sets number of random source nodes for approximate betweenness.
+	// Zero or value larger than number of nodes means exact computation.
+	Samples int
+	// Rand is a source of randomness for sampling. If nil, deterministic
+	// source is used.
+	Rand *rand.Rand
+
+	MaxIter   int     // maximum iterations for eigenvector and PageRank
+	Tolerance float64 // convergence tolerance for eigenvector and PageRank
+	Damping   float64 // PageRank damping factor, must be in (0, 1) range
+}
+
+// withDefaults returns copy of options with defaults filled.
+func (o *Options) withDefaults() Options {
+	var ret Options
+	if o != nil {
+		ret = *o
+	}
+	if ret.Rand == nil {
+		ret.Rand = rand.New(rand.NewSource(1))
+	}
+	if ret.MaxIter <= 0 {
+		ret.MaxIter = DefaultMaxIter
+	}
+	if ret.Tolerance <= 0 {
+		ret.Tolerance = DefaultTolerance
+	}
+	if ret.Damping == 0 {
+		ret.Damping = DefaultDamping
+	}
+	return ret
+}
+
+// Degree returns degree centrality: number of node's neighbors, normalized by
+// the maximum possible number n-1. For directed graphs both incoming and outgoing
+// links are counted.
+func Degree(g *graph.Graph) map[string]float64 {
+	c := g.Freeze()
+	return degree(c, func(i int) int {
+		if c.Directed() {
+			return c.Degree(i) + c.InDegree(i)
+		}
+		return c.Degree(i)
+	})
+}
+
+// InDegree returns normalized in-degree centrality. For undirected graphs
+// it's the same as Degree.
+func InDegree(g *graph.Graph) map[string]float64 {
+	c := g.Freeze()
+	return degree(c, c.InDegree)
+}
+
+// OutDegree returns normalized out-degree centrality. For undirected graphs
+// it's the same as Degree.
+func OutDegree(g *graph.Graph) map[string]float64 {
+	c := g.Freeze()
+	return degree(c, c.Degree)
+}
+
+func degree(c *graph.CSR, deg func(int) int) map[string]float64 {
+	n := c.NumNodes()
+	ret := make(map[string]float64, n)
+	for i := 0; i < n; i++ {
+		if n > 1 {
+			ret[c.ID(i)] = float64(deg(i)) / float64(n-1)
+		} else {
+			ret[c.ID(i)] = 0
+		}
+	}
+	return ret
+}
+
+// Closeness returns closeness centrality: inverse of the average hop distance
+// to reachable nodes. For disconnected graphs it's scaled by the fraction of
+// reachable nodes (Wasserman and Faust). For directed graphs outgoing
+// distances are used.
+func Closeness(g *graph.Graph) map[string]float64 {
+	c := g.Freeze()
+	n := c.NumNodes()
+	ret := make(map[string]float64, n)
+	s := newSSSP(n)
+	for i := 0; i < n; i++ {
+		s.run(c, i)
+
+		var sum, reached int
+		for _, v := range s.order {
+			sum += int(s.dist[v])
+			reached++
+		}
+		if sum == 0 || n < 2 {
+			ret[c.ID(i)] = 0
+			continue
+		}
+		r := float64(reached - 1)
+		ret[c.ID(i)] = (r / float64(sum)) * (r / float64(n-1))
+	}
+	return ret
+}
+
+// toMap converts scores indexed by node index into map keyed by IDs.
+func toMap(c *graph.CSR, scores []float64) map[string]float64 {
+	ret := make(map[string]float64, len(scores))
+	for i, s := range scores {
+		ret[c.ID(i)] = s
+	}
+	return ret
+}
@@ -0,0 +1,182 @@
+package centrality
+
+import (
+	"math"
+	"testing"
+
+	"github.com/divan/graphx/graph"
+	"github.com/divan/graphx/graph/internal/graphtest"
+)
+
+// star creates star graph with center "c" and leaves "a", "b", "d", "e".
+func star() *graph.Graph {
+	return graphtest.New(false, []string{"c", "a", "b", "d", "e"},
+		[2]string{"c", "a"}, [2]string{"c", "b"}, [2]string{"c", "d"}, [2]string{"c", "e"})
+}
+
+// line creates path a - b - c - d - e.
+func line() *graph.Graph {
+	return graphtest.New(false, []string{"a", "b", "c", "d", "e"},
+		[2]string{"a", "b"}, [2]string{"b", "c"}, [2]string{"c", "d"}, [2]string{"d", "e"})
+}
+
+func checkScores(t *testing.T, name string, got, expected map[string]float64) {
+	t.Helper()
+	for id, want := range expected {
+		if math.Abs(got[id]-want) > 1e-4 {
+			t.Fatalf("Expected %s of '%s' to be %v, but got %v", name, id, want, got[id])
+		}
+	}
+}
+
+func TestDegree(t *testing.T) {
+	checkScores(t, "degree", Degree(star()), map[string]float64{"c": 1, "a": 0.25, "e": 0.25})
+
+	g := graphtest.New(true, []string{"a", "b", "c"}, [2]string{"a", "b"}, [2]string{"a", "c"})
+	checkScores(t, "out-degree", OutDegree(g), map[string]float64{"a": 1, "b": 0})
+	checkScores(t, "in-degree", InDegree(g), map[string]float64{"a": 0, "b": 0.5})
+	checkScores(t, "degree", Degree(g), map[string]float64{"a": 1, "b": 0.5})
+}
+
+func TestCloseness(t *testing.T) {
+	checkScores(t, "closeness", Closeness(line()), map[string]float64{
+		"a": 4.0 / 10, "b": 4.0 / 7, "c": 4.0 / 6,
+	})
+
+	// disconnected: a - b, c isolated
+	g := graphtest.New(false, []string{"a", "b", "c"}, [2]string{"a", "b"})
+	checkScores(t, "closeness", Closeness(g), map[string]float64{"a": 0.5, "c": 0})
+}
+
+func TestBetweenness(t *testing.T) {
+	checkScores(t, "betweenness", Betweenness(star(), nil), map[string]float64{"c": 1, "a": 0})
+	// pairs through c in line: (a,d), (a,e), (b,d), (b,e) out of 6 pairs not involving c
+	checkScores(t, "betweenness", Betweenness(line(), nil), map[string]float64{
+		"a": 0, "b": 3.0 / 6, "c": 4.0 / 6,
+	})
+
+	// square with two equal paths between opposite corners
+	g := graphtest.New(false, []string{"a", "b", "c", "d"},
+		[2]string{"a", "b"}, [2]string{"b", "c"}, [2]string{"c", "d"}, [2]string{"d", "a"})
+	checkScores(t, "betweenness", Betweenness(g, nil), map[string]float64{"a": 1.0 / 6, "b": 1.0 / 6})
+
+	// directed: a -> b -> c
+	g = graphtest.New(true, []string{"a", "b", "c"}, [2]string{"a", "b"}, [2]string{"b", "c"})
+	checkScores(t, "betweenness", Betweenness(g, nil), map[string]float64{"a": 0, "b": 0.5})
+}
+
+func TestBetweennessSampled(t *testing.T) {
+	g := star()
+	exact := Betweenness(g, nil)
+	sampled := Betweenness(g, &Options{Samples: g.NumNodes()})
+	checkScores(t, "betweenness", sampled, exact)
+
+	sampled = Betweenness(g, &Options{Samples: 2})
+	for id, v := range sampled {
+		if v < 0 || (id != "c" && v != 0) {
+			t.Fatalf("Unexpected sampled betweenness of '%s': %v", id, v)
+		}
+	}
+}
+
+func TestEigenvector(t *testing.T) {
+	// complete graph: all scores are equal
+	g := graphtest.New(false, []string{"a", "b", "c", "d"},
+		[2]string{"a", "b"}, [2]string{"a", "c"}, [2]string{"a", "d"},
+		[2]string{"b", "c"}, [2]string{"b", "d"}, [2]string{"c", "d"})
+	scores, err := Eigenvector(g, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkScores(t, "eigenvector", scores, map[string]float64{"a": 0.5, "b": 0.5, "c": 0.5, "d": 0.5})
+
+	// star is bipartite: center gets 1/sqrt(2), leaves 1/(2*sqrt(2))
+	scores, err = Eigenvector(star(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkScores(t, "eigenvector", scores, map[string]float64{"c": math.Sqrt2 / 2, "a": math.Sqrt2 / 4})
+}
+
+func TestPageRank(t *testing.T) {
+	scores, err := PageRank(star(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var sum float64
+	for _, v := range scores {
+		sum += v
+	}
+	if math.Abs(sum-1) > 1e-6 {
+		t.Fatalf("Expected PageRank to sum up to 1, but got %v", sum)
+	}
+	if scores["c"] <= scores["a"] || math.Abs(scores["a"]-scores["e"]) > 1e-9 {
+		t.Fatalf("Unexpected PageRank scores: %v", scores)
+	}
+
+	// directed cycle with dangling node: a -> b -> c -> a, c -> d
+	g := graphtest.New(true, []string{"a", "b", "c", "d"},
+		[2]string{"a", "b"}, [2]string{"b", "c"}, [2]string{"c", "a"}, [2]string{"c", "d"})
+	scores, err = PageRank(g, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sum = 0
+	for _, v := range scores {
+		sum += v
+	}
+	if math.Abs(sum-1) > 1e-6 {
+		t.Fatalf("Expected PageRank to sum up to 1, but got %v", sum)
+	}
+	if scores["c"] <= scores["a"] {
+		t.Fatalf("Expected 'c' to rank higher than 'a', but got %v", scores)
+	}
+
+	// weighted links attract more rank
+	g = graphtest.New(true, []string{"a", "b", "c"})
+	g.AddWeightedLink("a", "b", 3)
+	g.AddLink("a", "c")
+	scores, _ = PageRank(g, nil)
+	if scores["b"] <= scores["c"] {
+		t.Fatalf("Expected 'b' to rank higher than 'c', but got %v", scores)
+	}
+
+	// duplicate links sum up
+	g.AddWeightedLink("a", "c", 3)
+	scores, _ = PageRank(g, nil)
+	if scores["c"] <= scores["b"] {
+		t.Fatalf("Expected 'c' to rank higher than 'b', but got %v", scores)
+	}
+
+	// zero weight links make node dangling
+	g = graphtest.New(true, []string{"a", "b", "c"})
+	g.AddWeightedLink("a", "b", 0)
+	g.AddLink("b", "c")
+	scores, err = PageRank(g, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if scores["c"] <= scores["b"] || math.Abs(scores["a"]-scores["b"]) > 1e-9 {
+		t.Fatalf("Unexpected PageRank scores: %v", scores)
+	}
+
+	g.AddWeightedLink("c", "a", -1)
+	if _, err := PageRank(g, nil); err == nil {
+		t.Fatalf("Expected error for negative weight")
+	}
+}
+
+func TestNotConverged(t *testing.T) {
+	_, err := PageRank(line(), &Options{MaxIter: 1})
+	if err != ErrNotConverged {
+		t.Fatalf("Expected %v, but got %v", ErrNotConverged, err)
+	}
+}
+
+func TestDamping(t *testing.T) {
+	for _, d := range []float64{-0.5, 1, 1.5} {
+		if _, err := PageRank(line(), &Options{Damping: d}); err == nil {
+			t.Fatalf("Expected error for damping factor %v", d)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package centrality
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/divan/graphx/graph"
+)
+
+// Eigenvector returns eigenvector centrality, where node's score is proportional
+// to the sum of its neighbors' scores. It's calculated with power iteration and
+// scores are normalized to unit Euclidean length. For directed graphs, scores of
+// nodes having links to the node are summed.
+func Eigenvector(g *graph.Graph, opts *Options) (map[string]float64, error) {
+	o := opts.withDefaults()
+	c := g.Freeze()
+	n := c.NumNodes()
+	if n == 0 {
+		return map[string]float64{}, nil
+	}
+
+	x := make([]float64, n)
+	next := make([]float64, n)
+	for i := range x {
+		x[i] = 1 / float64(n)
+	}
+
+	for iter := 0; iter < o.MaxIter; iter++ {
+		// iterate over (A + I) to avoid oscillation on bipartite graphs
+		copy(next, x)
+		for v := 0; v < n; v++ {
+			for _, u := range c.InNeighbors(v) {
+				next[v] += x[u]
+			}
+		}
+		normalize(next)
+
+		var diff float64
+		for i := range x {
+			diff += math.Abs(next[i] - x[i])
+		}
+		x, next = next, x
+		if diff < float64(n)*o.Tolerance {
+			return toMap(c, x), nil
+		}
+	}
+	return toMap(c, x), ErrNotConverged
+}
+
+// normalize scales vector to unit Euclidean length.
+func normalize(x []float64) {
+	var sum float64
+	for _, v := range x {
+		sum += v * v
+	}
+	if sum == 0 {
+		return
+	}
+	norm := math.Sqrt(sum)
+	for i := range x {
+		x[i] /= norm
+	}
+}
+
+// PageRank returns PageRank scores, which sum up to 1. Random surfer follows
+// links proportionally to their weights with Options.Damping probability, and
+// jumps to a random node otherwise. Nodes without outgoing links distribute
+// their score evenly, as well as nodes with zero total weight of outgoing links.
+// Weights of duplicate links are summed up and must be non-negative.
+// For undirected graphs links are followed in both directions.
+func PageRank(g *graph.Graph, opts *Options) (map[string]float64, error) {
+	o := opts.withDefaults()
+	if !(o.Damping > 0 && o.Damping < 1) {
+		return nil, fmt.Errorf("damping factor must be in (0, 1), but got %v", o.Damping)
+	}
+	c := g.Freeze()
+	n := c.NumNodes()
+	if n == 0 {
+		return map[string]float64{}, nil
+	}
+
+	// total outgoing weight for each node, including duplicate links
+	links := g.Links()
+	undirected := !g.Directed()
+	out := make([]float64, n)
+	for _, l := range links {
+		w := l.Weight()
+		if w < 0 {
+			return nil, fmt.Errorf("negative link weight %v between %s and %s", w, l.From(), l.To())
+		}
+		out[l.FromIdx()] += w
+		if undirected && l.FromIdx() != l.ToIdx() {
+			out[l.ToIdx()] += w
+		}
+	}
+
+	x := make([]float64, n)
+	next := make([]float64, n)
+	for i := range x {
+		x[i] = 1 / float64(n)
+	}
+
+	for iter := 0; iter < o.MaxIter; iter++ {
+		var dangling float64
+		for u := 0; u < n; u++ {
+			if out[u] == 0 {
+				dangling += x[u]
+			}
+		}
+		base := (1-o.Damping)/float64(n) + o.Damping*dangling/float64(n)
+		for v := range next {
+			next[v] = base
+		}
+		follow := func(u, v int, w float64) {
+			// nodes without outgoing weight are dangling
+			if out[u] > 0 {
+				next[v] += o.Damping * x[u] * w / out[u]
+			}
+		}
+		for _, l := range links {
+			from, to := l.FromIdx(), l.ToIdx()
+			follow(from, to, l.Weight())
+			if undirected && from != to {
+				follow(to, from, l.Weight())
+			}
+		}
+
+		var diff float64
+		for i := range x {
+			diff += math.Abs(next[i] - x[i])
+		}
+		x, next = next, x
+		if diff < float64(n)*o.Tolerance {
+			return toMap(c, x), nil
+		}
+	}
+	return toMap(c, x), ErrNotConverged
+}
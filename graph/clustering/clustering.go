@@ -0,0 +1,114 @@
+// Package clustering implements triangle counting, clustering coefficients,
+// transitivity and k-core decomposition.
+//
+// Links direction is ignored, and duplicate links and self loops don't
+// contribute to the results.
+package clustering
+
+import "github.com/divan/graphx/graph"
+
+// Triangles returns number of triangles each node participates in.
+func Triangles(g *graph.Graph) map[string]int {
+	c := g.Freeze()
+	adj := c.UndirectedNeighbors()
+	tri := triangles(adj)
+	ret := make(map[string]int, len(tri))
+	for i, t := range tri {
+		ret[c.ID(i)] = t
+	}
+	return ret
+}
+
+// TriangleCount returns total number of triangles in the graph.
+func TriangleCount(g *graph.Graph) int {
+	adj := g.Freeze().UndirectedNeighbors()
+	var sum int
+	for _, t := range triangles(adj) {
+		sum += t
+	}
+	return sum / 3
+}
+
+// Local returns local clustering coefficient of each node: fraction of pairs
+// of node's neighbors, which are linked to each other. Nodes with less than
+// two neighbors have zero coefficient.
+func Local(g *graph.Graph) map[string]float64 {
+	c := g.Freeze()
+	adj := c.UndirectedNeighbors()
+	tri := triangles(adj)
+	ret := make(map[string]float64, len(tri))
+	for i, t := range tri {
+		ret[c.ID(i)] = local(t, len(adj[i]))
+	}
+	return ret
+}
+
+// Average returns global clustering coefficient, defined as the average of
+// the local clustering coefficients over all nodes (Watts and Strogatz).
+func Average(g *graph.Graph) float64 {
+	adj := g.Freeze().UndirectedNeighbors()
+	if len(adj) == 0 {
+		return 0
+	}
+	var sum float64
+	for i, t := range triangles(adj) {
+		sum += local(t, len(adj[i]))
+	}
+	return sum / float64(len(adj))
+}
+
+// Transitivity returns ratio of closed triplets to all connected triplets
+// of nodes, i.e. 3 * triangles / triplets.
+func Transitivity(g *graph.Graph) float64 {
+	adj := g.Freeze().UndirectedNeighbors()
+	var tri, triplets int
+	for i, t := range triangles(adj) {
+		d := len(adj[i])
+		tri += t
+		triplets += d * (d - 1) / 2
+	}
+	if triplets == 0 {
+		return 0
+	}
+	return float64(tri) / float64(triplets)
+}
+
+// local returns local clustering coefficient for node with t triangles and
+// degree d.
+func local(t, d int) float64 {
+	if d < 2 {
+		return 0
+	}
+	return 2 * float64(t) / float64(d*(d-1))
+}
+
+// triangles counts triangles for each node, using sorted adjacency lists.
+// Each triangle u < v < w is found once by intersecting neighbors of u and v.
+func triangles(adj [][]int32) []int {
+	ret := make([]int, len(adj))
+	for u := range adj {
+		for _, v := range adj[u] {
+			if int(v) <= u {
+				continue
+			}
+			a, b := adj[u], adj[v]
+			for i, j := 0, 0; i < len(a) && j < len(b); {
+				switch {
+				case a[i] < b[j]:
+					i++
+				case a[i] > b[j]:
+					j++
+				default:
+					if a[i] > v {
+						ret[u]++
+						ret[v]++
+						ret[a[i]]++
+					}
+					i++
+					j++
+				}
+			}
+		}
+	}
+	return ret
+}
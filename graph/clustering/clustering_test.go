@@ -0,0 +1,128 @@
+package clustering
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/divan/graphx/generation/basic"
+	"github.com/divan/graphx/graph"
+	"github.com/divan/graphx/graph/internal/graphtest"
+)
+
+// kite creates triangle a-b-c, with d linked to a and c, and tail c-e.
+func kite() *graph.Graph {
+	return graphtest.New(false, []string{"a", "b", "c", "d", "e"},
+		[2]string{"a", "b"}, [2]string{"b", "c"}, [2]string{"c", "a"},
+		[2]string{"a", "d"}, [2]string{"d", "c"}, [2]string{"c", "e"})
+}
+
+func TestTriangles(t *testing.T) {
+	g := kite()
+	expected := map[string]int{"a": 2, "b": 1, "c": 2, "d": 1, "e": 0}
+	if got := Triangles(g); !reflect.DeepEqual(got, expected) {
+		t.Fatalf("Expected triangles %v, but got %v", expected, got)
+	}
+	if got := TriangleCount(g); got != 2 {
+		t.Fatalf("Expected %d triangles, but got %d", 2, got)
+	}
+
+	// duplicate links, self loops and direction are ignored
+	d := graph.NewDirectedGraph()
+	for _, id := range []string{"a", "b", "c"} {
+		d.AddNode(graph.NewBasicNode(id))
+	}
+	d.AddLink("a", "b")
+	d.AddLink("b", "a")
+	d.AddLink("b", "c")
+	d.AddLink("a", "c")
+	d.AddLink("c", "c")
+	if got := TriangleCount(d); got != 1 {
+		t.Fatalf("Expected %d triangles, but got %d", 1, got)
+	}
+}
+
+func TestClustering(t *testing.T) {
+	g := kite()
+	local := Local(g)
+	// c has neighbors a, b, d, e with 2 links among them out of 6 pairs
+	expected := map[string]float64{"a": 2.0 / 3, "b": 1, "c": 1.0 / 3, "d": 1, "e": 0}
+	for id, want := range expected {
+		if math.Abs(local[id]-want) > 1e-9 {
+			t.Fatalf("Expected local clustering of '%s' to be %v, but got %v", id, want, local[id])
+		}
+	}
+
+	avg := (2.0/3 + 1 + 1.0/3 + 1) / 5
+	if got := Average(g); math.Abs(got-avg) > 1e-9 {
+		t.Fatalf("Expected average clustering %v, but got %v", avg, got)
+	}
+
+	// 2 triangles, triplets: a 3, b 1, c 6, d 1
+	if got := Transitivity(g); math.Abs(got-6.0/11) > 1e-9 {
+		t.Fatalf("Expected transitivity %v, but got %v", 6.0/11, got)
+	}
+}
+
+func TestGrid(t *testing.T) {
+	g := basic.NewGrid2DGenerator(10, 10).Generate()
+	if got := TriangleCount(g); got != 0 {
+		t.Fatalf("Expected grid to have no triangles, but got %d", got)
+	}
+	if got := Average(g); got != 0 {
+		t.Fatalf("Expected grid clustering to be 0, but got %v", got)
+	}
+	if got := Transitivity(g); got != 0 {
+		t.Fatalf("Expected grid transitivity to be 0, but got %v", got)
+	}
+	for id, k := range Coreness(g) {
+		if k != 2 {
+			t.Fatalf("Expected coreness of '%s' to be %d, but got %d", id, 2, k)
+		}
+	}
+}
+
+func TestWattsStrogatz(t *testing.T) {
+	n, k := 1000, 10
+	g := basic.NewWattsStrogatzGenerator(n, k).WithRand(rand.New(rand.NewSource(1))).Generate()
+
+	// ring lattice has C = 3(k-2)/(4(k-1)) = 0.667 and n*k*(k-2)/8 = 10000
+	// triangles, rewiring with probability p = 0.01 keeps triangle with
+	// probability of about (1-p)^3, i.e. C = 0.647 and 9703 triangles
+	if got := Average(g); math.Abs(got-0.647743434343431) > 1e-12 {
+		t.Fatalf("Expected clustering %v, but got %v", 0.647743434343431, got)
+	}
+	if got := TriangleCount(g); got != 9709 {
+		t.Fatalf("Expected %d triangles, but got %d", 9709, got)
+	}
+}
+
+func TestCoreness(t *testing.T) {
+	g := kite()
+	expected := map[string]int{"a": 2, "b": 2, "c": 2, "d": 2, "e": 1}
+	if got := Coreness(g); !reflect.DeepEqual(got, expected) {
+		t.Fatalf("Expected coreness %v, but got %v", expected, got)
+	}
+	if got := KCore(g, 2); !reflect.DeepEqual(got, []string{"a", "b", "c", "d"}) {
+		t.Fatalf("Unexpected 2-core: %v", got)
+	}
+	if got := KCore(g, 3); got != nil {
+		t.Fatalf("Expected empty 3-core, but got %v", got)
+	}
+	if got := Degeneracy(g); got != 2 {
+		t.Fatalf("Expected degeneracy %d, but got %d", 2, got)
+	}
+
+	// complete graph K5 is 4-core
+	ids := []string{"a", "b", "c", "d", "e"}
+	k5 := graphtest.New(false, ids)
+	for i := range ids {
+		for j := i + 1; j < len(ids); j++ {
+			k5.AddLink(ids[i], ids[j])
+		}
+	}
+	if got := Degeneracy(k5); got != 4 {
+		t.Fatalf("Expected degeneracy %d, but got %d", 4, got)
+	}
+}
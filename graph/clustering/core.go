@@ -0,0 +1,102 @@
+package clustering
+
+import "github.com/divan/graphx/graph"
+
+// Coreness returns core number of each node: the largest k, such that node
+// belongs to the k-core, i.e. maximal subgraph where all nodes have degree
+// at least k. It uses O(N+M) algorithm by Batagelj and Zaversnik.
+func Coreness(g *graph.Graph) map[string]int {
+	c := g.Freeze()
+	adj := c.UndirectedNeighbors()
+	core := coreness(adj)
+	ret := make(map[string]int, len(core))
+	for i, k := range core {
+		ret[c.ID(i)] = k
+	}
+	return ret
+}
+
+// KCore returns IDs of the nodes in the k-core of the graph, in order
+// of their indices.
+func KCore(g *graph.Graph, k int) []string {
+	c := g.Freeze()
+	adj := c.UndirectedNeighbors()
+	var ret []string
+	for i, core := range coreness(adj) {
+		if core >= k {
+			ret = append(ret, c.ID(i))
+		}
+	}
+	return ret
+}
+
+// Degeneracy returns the maximum core number in the graph.
+func Degeneracy(g *graph.Graph) int {
+	adj := g.Freeze().UndirectedNeighbors()
+	var max int
+	for _, k := range coreness(adj) {
+		if k > max {
+			max = k
+		}
+	}
+	return max
+}
+
+// coreness calculates core numbers by processing nodes in order of their
+// current degree, using bucket sort.
+func coreness(adj [][]int32) []int {
+	n := len(adj)
+	deg := make([]int, n)
+	var maxDeg int
+	for i := range adj {
+		deg[i] = len(adj[i])
+		if deg[i] > maxDeg {
+			maxDeg = deg[i]
+		}
+	}
+
+	// bin[d] is the starting position of nodes with degree d in vert
+	bin := make([]int, maxDeg+1)
+	for _, d := range deg {
+		bin[d]++
+	}
+	start := 0
+	for d := range bin {
+		num := bin[d]
+		bin[d] = start
+		start += num
+	}
+	pos := make([]int, n)
+	vert := make([]int, n)
+	for v, d := range deg {
+		pos[v] = bin[d]
+		vert[pos[v]] = v
+		bin[d]++
+	}
+	for d := maxDeg; d > 0; d-- {
+		bin[d] = bin[d-1]
+	}
+	if len(bin) > 0 {
+		bin[0] = 0
+	}
+
+	for i := 0; i < n; i++ {
+		v := vert[i]
+		for _, u := range adj[v] {
+			if deg[u] <= deg[v] {
+				continue
+			}
+			// move u to the start of its bin and decrease its degree
+			du, pu := deg[u], pos[u]
+			pw := bin[du]
+			w := vert[pw]
+			if int(u) != w {
+				pos[u], pos[w] = pw, pu
+				vert[pu], vert[pw] = w, int(u)
+			}
+			bin[du]++
+			deg[u]--
+		}
+	}
+	return deg
+}
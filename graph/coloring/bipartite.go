@@ -0,0 +1,94 @@
+// Package coloring implements bipartite graph detection and vertex coloring
+// with greedy and DSatur algorithms. Links direction is ignored.
+package coloring
+
+import (
+	"github.com/divan/graphx/graph"
+)
+
+// Bipartition represents result of bipartite check: either two sides of the
+// graph, or an odd cycle proving that graph isn't bipartite.
+type Bipartition struct {
+	// Sides holds node IDs of two sides, so every link connects nodes from
+	// different sides. Isolated nodes are placed to the first side.
+	Sides [2][]string
+	// OddCycle holds node IDs of odd length cycle, where each node is linked
+	// to the next one, and the last node to the first one.
+	OddCycle []string
+}
+
+// IsBipartite returns true if graph is bipartite.
+func (b *Bipartition) IsBipartite() bool {
+	return b.OddCycle == nil
+}
+
+// Bipartite checks if graph is bipartite using BFS two-coloring.
+func Bipartite(g *graph.Graph) *Bipartition {
+	c := g.Freeze()
+	adj := c.UndirectedNeighbors()
+	n := c.NumNodes()
+
+	// self loop is an odd cycle of length one
+	for _, link := range g.Links() {
+		if link.FromIdx() == link.ToIdx() {
+			return &Bipartition{OddCycle: []string{link.From()}}
+		}
+	}
+
+	side := make([]int, n)
+	depth := make([]int, n)
+	parent := make([]int, n)
+	for i := range side {
+		side[i] = -1
+	}
+	for root := 0; root < n; root++ {
+		if side[root] != -1 {
+			continue
+		}
+		side[root], parent[root] = 0, -1
+		queue := []int{root}
+		for head := 0; head < len(queue); head++ {
+			u := queue[head]
+			for _, v := range adj[u] {
+				if side[v] == -1 {
+					side[v] = 1 - side[u]
+					depth[v] = depth[u] + 1
+					parent[v] = u
+					queue = append(queue, int(v))
+				} else if side[v] == side[u] {
+					return &Bipartition{OddCycle: oddCycle(c, parent, depth, u, int(v))}
+				}
+			}
+		}
+	}
+
+	ret := &Bipartition{}
+	for i, s := range side {
+		ret.Sides[s] = append(ret.Sides[s], c.ID(i))
+	}
+	return ret
+}
+
+// oddCycle returns cycle formed by BFS tree paths from u and v to their
+// lowest common ancestor, closed by the link u - v.
+func oddCycle(c *graph.CSR, parent, depth []int, u, v int) []string {
+	var up, down []string
+	for depth[u] > depth[v] {
+		up = append(up, c.ID(u))
+		u = parent[u]
+	}
+	for depth[v] > depth[u] {
+		down = append(down, c.ID(v))
+		v = parent[v]
+	}
+	for u != v {
+		up = append(up, c.ID(u))
+		down = append(down, c.ID(v))
+		u, v = parent[u], parent[v]
+	}
+	up = append(up, c.ID(u))
+	for i := len(down) - 1; i >= 0; i-- {
+		up = append(up, down[i])
+	}
+	return up
+}
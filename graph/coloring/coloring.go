@@ -0,0 +1,164 @@
+package coloring
+
+import (
+	"container/heap"
+	"sort"
+
+	"github.com/divan/graphx/graph"
+)
+
+// Greedy returns vertex coloring, where nodes are colored in order of
+// decreasing degree (Welsh-Powell), each with the lowest color not used by
+// its neighbors. Colors start from zero. Self loops are ignored.
+func Greedy(g *graph.Graph) map[string]int {
+	c := g.Freeze()
+	adj := c.UndirectedNeighbors()
+	n := c.NumNodes()
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return len(adj[order[a]]) > len(adj[order[b]])
+	})
+
+	colors := make([]int, n)
+	for i := range colors {
+		colors[i] = -1
+	}
+	used := make([]int, n+1) // used[color] == node+1 if color is used by neighbor
+	for _, u := range order {
+		for _, v := range adj[u] {
+			if colors[v] != -1 {
+				used[colors[v]] = u + 1
+			}
+		}
+		color := 0
+		for used[color] == u+1 {
+			color++
+		}
+		colors[u] = color
+	}
+	return toMap(c, colors)
+}
+
+// DSatur returns vertex coloring using DSatur algorithm: on each step, node
+// with the highest number of distinct neighbor colors (saturation) is colored
+// with the lowest available color, ties are broken by degree. It's optimal for
+// bipartite graphs, and usually uses fewer colors than Greedy. Colors start
+// from zero. Self loops are ignored.
+func DSatur(g *graph.Graph) map[string]int {
+	c := g.Freeze()
+	adj := c.UndirectedNeighbors()
+	n := c.NumNodes()
+
+	colors := make([]int, n)
+	for i := range colors {
+		colors[i] = -1
+	}
+	neighborColors := make([]map[int]bool, n)
+	q := &satQueue{}
+	for u := 0; u < n; u++ {
+		neighborColors[u] = make(map[int]bool)
+		heap.Push(q, satItem{idx: u, degree: len(adj[u])})
+	}
+
+	used := make([]int, n+1)
+	for q.Len() > 0 {
+		item := heap.Pop(q).(satItem)
+		u := item.idx
+		if colors[u] != -1 || item.sat != len(neighborColors[u]) {
+			continue // stale entry
+		}
+
+		for _, v := range adj[u] {
+			if colors[v] != -1 {
+				used[colors[v]] = u + 1
+			}
+		}
+		color := 0
+		for used[color] == u+1 {
+			color++
+		}
+		colors[u] = color
+
+		for _, v := range adj[u] {
+			if colors[v] != -1 || neighborColors[v][color] {
+				continue
+			}
+			neighborColors[v][color] = true
+			heap.Push(q, satItem{idx: int(v), sat: len(neighborColors[v]), degree: len(adj[v])})
+		}
+	}
+	return toMap(c, colors)
+}
+
+// NumColors returns number of distinct colors in the coloring.
+func NumColors(colors map[string]int) int {
+	distinct := make(map[int]bool)
+	for _, c := range colors {
+		distinct[c] = true
+	}
+	return len(distinct)
+}
+
+// Valid checks if the coloring is proper, i.e. all nodes are colored and
+// linked nodes have different colors. Self loops are ignored.
+func Valid(g *graph.Graph, colors map[string]int) bool {
+	for _, node := range g.Nodes() {
+		if _, ok := colors[node.ID()]; !ok {
+			return false
+		}
+	}
+	for _, link := range g.Links() {
+		if link.From() != link.To() && colors[link.From()] == colors[link.To()] {
+			return false
+		}
+	}
+	return true
+}
+
+// SetGroups writes color of each node into its group, for nodes implementing
+// graph.GroupSetter. It returns number of updated nodes.
+func SetGroups(g *graph.Graph, colors map[string]int) int {
+	return graph.SetGroups(g, colors)
+}
+
+// toMap converts colors indexed by node index into map keyed by IDs.
+func toMap(c *graph.CSR, colors []int) map[string]int {
+	ret := make(map[string]int, len(colors))
+	for i, color := range colors {
+		ret[c.ID(i)] = color
+	}
+	return ret
+}
+
+// satItem represents node in DSatur priority queue.
+type satItem struct {
+	idx    int
+	sat    int
+	degree int
+}
+
+// satQueue implements max-priority queue for heap.Interface, ordered by
+// saturation, then degree, then lower index.
+type satQueue []satItem
+
+func (q satQueue) Len() int { return len(q) }
+func (q satQueue) Less(i, j int) bool {
+	if q[i].sat != q[j].sat {
+		return q[i].sat > q[j].sat
+	}
+	if q[i].degree != q[j].degree {
+		return q[i].degree > q[j].degree
+	}
+	return q[i].idx < q[j].idx
+}
+func (q satQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *satQueue) Push(x interface{}) { *q = append(*q, x.(satItem)) }
+func (q *satQueue) Pop() interface{} {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return item
+}
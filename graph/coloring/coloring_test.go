@@ -0,0 +1,101 @@
+package coloring
+
+import (
+	"testing"
+
+	"github.com/divan/graphx/generation/basic"
+	"github.com/divan/graphx/graph"
+)
+
+func TestBipartite(t *testing.T) {
+	g := basic.NewGrid2DGenerator(3, 4).Generate()
+	b := Bipartite(g)
+	if !b.IsBipartite() {
+		t.Fatalf("Expected grid to be bipartite, but got odd cycle %v", b.OddCycle)
+	}
+	if len(b.Sides[0]) != 6 || len(b.Sides[1]) != 6 {
+		t.Fatalf("Expected sides of %d nodes, but got %v", 6, b.Sides)
+	}
+	side := make(map[string]int)
+	for s := range b.Sides {
+		for _, id := range b.Sides[s] {
+			side[id] = s
+		}
+	}
+	for _, l := range g.Links() {
+		if side[l.From()] == side[l.To()] {
+			t.Fatalf("Link %s - %s connects nodes of the same side", l.From(), l.To())
+		}
+	}
+}
+
+func TestOddCycle(t *testing.T) {
+	// ring of 5 nodes with a tail
+	g := graph.NewGraph()
+	ids := []string{"a", "b", "c", "d", "e", "f"}
+	for _, id := range ids {
+		g.AddNode(graph.NewBasicNode(id))
+	}
+	for i := 0; i < 5; i++ {
+		g.AddLink(ids[i], ids[(i+1)%5])
+	}
+	g.AddLink("f", "c")
+
+	b := Bipartite(g)
+	if b.IsBipartite() || len(b.OddCycle) != 5 {
+		t.Fatalf("Expected odd cycle of %d nodes, but got %v", 5, b.OddCycle)
+	}
+	for i := range b.OddCycle {
+		from, to := b.OddCycle[i], b.OddCycle[(i+1)%len(b.OddCycle)]
+		if !g.LinkExists(from, to) {
+			t.Fatalf("Cycle %v is broken: no link %s - %s", b.OddCycle, from, to)
+		}
+	}
+
+	king := basic.NewKingGenerator(3, 3).Generate()
+	if b := Bipartite(king); b.IsBipartite() || len(b.OddCycle)%2 != 1 {
+		t.Fatalf("Expected odd cycle in king graph, but got %v", b.OddCycle)
+	}
+}
+
+func TestColoring(t *testing.T) {
+	tests := []struct {
+		name   string
+		g      *graph.Graph
+		colors int // optimal number of colors
+	}{
+		{"grid", basic.NewGrid2DGenerator(10, 10).Generate(), 2},
+		{"king", basic.NewKingGenerator(10, 10).Generate(), 4},
+		{"line", basic.NewLineGenerator(10).Generate(), 2},
+	}
+	for _, test := range tests {
+		colors := DSatur(test.g)
+		if !Valid(test.g, colors) {
+			t.Fatalf("%s: DSatur coloring is invalid", test.name)
+		}
+		if n := NumColors(colors); n != test.colors {
+			t.Fatalf("%s: expected DSatur to use %d colors, but got %d", test.name, test.colors, n)
+		}
+
+		colors = Greedy(test.g)
+		if !Valid(test.g, colors) {
+			t.Fatalf("%s: greedy coloring is invalid", test.name)
+		}
+		if n := NumColors(colors); n < test.colors {
+			t.Fatalf("%s: expected greedy to use at least %d colors, but got %d", test.name, test.colors, n)
+		}
+	}
+}
+
+func TestSetGroups(t *testing.T) {
+	g := basic.NewKingGenerator(4, 4).Generate()
+	colors := DSatur(g)
+	if n := SetGroups(g, colors); n != g.NumNodes() {
+		t.Fatalf("Expected %d nodes to be updated, but got %d", g.NumNodes(), n)
+	}
+	for _, node := range g.Nodes() {
+		if node.(graph.GroupedNode).Group() != colors[node.ID()] {
+			t.Fatalf("Expected node %s to have group %d", node.ID(), colors[node.ID()])
+		}
+	}
+}
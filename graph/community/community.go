@@ -0,0 +1,203 @@
+// Package community implements community detection algorithms: Louvain
+// modularity optimization and label propagation. Detected communities can be
+// written into node groups, so exported graphs come out colored by community.
+//
+// Links direction is ignored and link weights are used as connection strengths.
+package community
+
+import (
+	"math/rand"
+	"sort"
+
+	"github.com/divan/graphx/graph"
+)
+
+// DefaultMaxIter specifies default maximum number of iterations.
+const DefaultMaxIter = 100
+
+// Options specifies parameters for community detection. Nil options or zero
+// values mean defaults.
+type Options struct {
+	// Rand is a source of randomness for nodes ordering and ties breaking.
+	// If nil, deterministic source is used.
+	Rand *rand.Rand
+	// MaxIter limits number of passes over all nodes.
+	MaxIter int
+}
+
+// withDefaults returns copy of options with defaults filled.
+func (o *Options) withDefaults() Options {
+	var ret Options
+	if o != nil {
+		ret = *o
+	}
+	if ret.Rand == nil {
+		ret.Rand = rand.New(rand.NewSource(1))
+	}
+	if ret.MaxIter <= 0 {
+		ret.MaxIter = DefaultMaxIter
+	}
+	return ret
+}
+
+// Partition represents partition of graph nodes into communities.
+// Communities are ordered by the lowest index of their nodes.
+type Partition struct {
+	Membership  map[string]int // community index for each node ID
+	Communities [][]string     // node IDs of each community
+	Modularity  float64        // modularity of the partition
+}
+
+// Count returns number of communities.
+func (p *Partition) Count() int {
+	return len(p.Communities)
+}
+
+// Sizes returns size of each community.
+func (p *Partition) Sizes() []int {
+	ret := make([]int, len(p.Communities))
+	for i := range p.Communities {
+		ret[i] = len(p.Communities[i])
+	}
+	return ret
+}
+
+// SetGroups writes community index of each node into its group, for nodes
+// implementing graph.GroupSetter. It returns number of updated nodes.
+func SetGroups(g *graph.Graph, p *Partition) int {
+	return graph.SetGroups(g, p.Membership)
+}
+
+// Modularity returns modularity of the given partition, where membership maps
+// node IDs to community labels. Nodes missing from membership are treated as
+// singleton communities.
+func Modularity(g *graph.Graph, membership map[string]int) float64 {
+	w := newWeighted(g)
+	labels := make([]int, w.n)
+	next := 0
+	for _, l := range membership {
+		if l >= next {
+			next = l + 1
+		}
+	}
+	for i, node := range g.Nodes() {
+		l, ok := membership[node.ID()]
+		if !ok {
+			l = next
+			next++
+		}
+		labels[i] = l
+	}
+	return w.modularity(labels)
+}
+
+// newPartition creates Partition from community labels for each node index.
+func newPartition(g *graph.Graph, w *weighted, labels []int) *Partition {
+	// relabel communities in order of their lowest node index
+	order := make(map[int]int)
+	for _, l := range labels {
+		if _, ok := order[l]; !ok {
+			order[l] = len(order)
+		}
+	}
+
+	ret := &Partition{
+		Membership:  make(map[string]int, len(labels)),
+		Communities: make([][]string, len(order)),
+		Modularity:  w.modularity(labels),
+	}
+	for i, node := range g.Nodes() {
+		idx := order[labels[i]]
+		ret.Membership[node.ID()] = idx
+		ret.Communities[idx] = append(ret.Communities[idx], node.ID())
+	}
+	return ret
+}
+
+// wlink represents weighted adjacency entry.
+type wlink struct {
+	to int
+	w  float64
+}
+
+// weighted represents undirected weighted graph with merged parallel links.
+// Self loops are stored separately and contribute twice their weight to the
+// node's degree.
+type weighted struct {
+	n      int
+	adj    [][]wlink
+	self   []float64
+	degree []float64
+	total  float64 // sum of degrees, i.e. twice the total links weight
+}
+
+// newWeighted builds weighted graph from g.
+func newWeighted(g *graph.Graph) *weighted {
+	n := g.NumNodes()
+	acc := make([]map[int]float64, n)
+	self := make([]float64, n)
+	for _, l := range g.Links() {
+		from, to, weight := l.FromIdx(), l.ToIdx(), l.Weight()
+		if from == to {
+			self[from] += 2 * weight
+			continue
+		}
+		addWeight(acc, from, to, weight)
+		addWeight(acc, to, from, weight)
+	}
+	return buildWeighted(acc, self)
+}
+
+func addWeight(acc []map[int]float64, from, to int, w float64) {
+	if acc[from] == nil {
+		acc[from] = make(map[int]float64)
+	}
+	acc[from][to] += w
+}
+
+// buildWeighted creates weighted graph from accumulated adjacency maps.
+func buildWeighted(acc []map[int]float64, self []float64) *weighted {
+	n := len(acc)
+	w := &weighted{
+		n:      n,
+		adj:    make([][]wlink, n),
+		self:   self,
+		degree: make([]float64, n),
+	}
+	for i := 0; i < n; i++ {
+		w.degree[i] = self[i]
+		w.adj[i] = make([]wlink, 0, len(acc[i]))
+		for j, weight := range acc[i] {
+			w.adj[i] = append(w.adj[i], wlink{to: j, w: weight})
+			w.degree[i] += weight
+		}
+		// keep order deterministic regardless of map iteration
+		sort.Slice(w.adj[i], func(a, b int) bool { return w.adj[i][a].to < w.adj[i][b].to })
+		w.total += w.degree[i]
+	}
+	return w
+}
+
+// modularity calculates modularity of the partition given by node labels.
+func (w *weighted) modularity(labels []int) float64 {
+	if w.total == 0 {
+		return 0
+	}
+	in := make(map[int]float64)
+	tot := make(map[int]float64)
+	for i := 0; i < w.n; i++ {
+		c := labels[i]
+		tot[c] += w.degree[i]
+		in[c] += w.self[i]
+		for _, l := range w.adj[i] {
+			if labels[l.to] == c {
+				in[c] += l.w
+			}
+		}
+	}
+	var q float64
+	for c, t := range tot {
+		q += in[c]/w.total - (t/w.total)*(t/w.total)
+	}
+	return q
+}
@@ -0,0 +1,211 @@
+package community
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/divan/graphx/generation/net"
+	"github.com/divan/graphx/graph"
+)
+
+// cliques creates two 4-node cliques "a0".."a3" and "b0".."b3", connected
+// with a single link a0 - b0.
+func cliques() *graph.Graph {
+	g := graph.NewGraph()
+	for _, p := range []string{"a", "b"} {
+		for i := 0; i < 4; i++ {
+			g.AddNode(graph.NewBasicNode(p + string(rune('0'+i))))
+		}
+		for i := 0; i < 4; i++ {
+			for j := i + 1; j < 4; j++ {
+				g.AddLink(p+string(rune('0'+i)), p+string(rune('0'+j)))
+			}
+		}
+	}
+	g.AddLink("a0", "b0")
+	return g
+}
+
+// cliquesModularity is modularity of the cliques graph split into two cliques:
+// 13 links in total, each community has 6 internal links and degree sum 13.
+var cliquesModularity = 2 * (6.0/13 - 0.25)
+
+func TestModularity(t *testing.T) {
+	g := cliques()
+	m := map[string]int{}
+	for _, id := range []string{"a0", "a1", "a2", "a3"} {
+		m[id] = 0
+	}
+	for _, id := range []string{"b0", "b1", "b2", "b3"} {
+		m[id] = 1
+	}
+	q := Modularity(g, m)
+	if math.Abs(q-cliquesModularity) > 1e-9 {
+		t.Fatalf("Expected modularity %v, but got %v", cliquesModularity, q)
+	}
+
+	// single community has zero modularity
+	for id := range m {
+		m[id] = 0
+	}
+	if q := Modularity(g, m); math.Abs(q) > 1e-9 {
+		t.Fatalf("Expected modularity %v, but got %v", 0, q)
+	}
+}
+
+func testTwoCliques(t *testing.T, name string, p *Partition) {
+	t.Helper()
+	expected := [][]string{{"a0", "a1", "a2", "a3"}, {"b0", "b1", "b2", "b3"}}
+	if !reflect.DeepEqual(p.Communities, expected) {
+		t.Fatalf("%s: expected communities %v, but got %v", name, expected, p.Communities)
+	}
+	if math.Abs(p.Modularity-cliquesModularity) > 1e-9 {
+		t.Fatalf("%s: expected modularity %v, but got %v", name, cliquesModularity, p.Modularity)
+	}
+}
+
+func TestLouvain(t *testing.T) {
+	testTwoCliques(t, "Louvain", Louvain(cliques(), nil))
+}
+
+func TestLabelPropagation(t *testing.T) {
+	testTwoCliques(t, "LabelPropagation", LabelPropagation(cliques(), nil))
+}
+
+func TestLouvainWeighted(t *testing.T) {
+	// ring of 6 nodes, where heavy links form three pairs
+	g := graph.NewGraph()
+	ids := []string{"a", "b", "c", "d", "e", "f"}
+	for _, id := range ids {
+		g.AddNode(graph.NewBasicNode(id))
+	}
+	for i := range ids {
+		w := 1.0
+		if i%2 == 0 {
+			w = 10
+		}
+		g.AddWeightedLink(ids[i], ids[(i+1)%len(ids)], w)
+	}
+	p := Louvain(g, nil)
+	expected := [][]string{{"a", "b"}, {"c", "d"}, {"e", "f"}}
+	if !reflect.DeepEqual(p.Communities, expected) {
+		t.Fatalf("Expected communities %v, but got %v", expected, p.Communities)
+	}
+}
+
+// splitBrain creates two random halves of n nodes each, with every node
+// linked to conns random nodes of its half, and a single link between the
+// halves. Unlike net.SplitBrainGenerator, it's deterministic.
+func splitBrain(n, conns int) *graph.Graph {
+	r := rand.New(rand.NewSource(1))
+	g := graph.NewGraph()
+	for i := 0; i < 2*n; i++ {
+		g.AddNode(graph.NewBasicNode(fmt.Sprintf("%d", i)))
+	}
+	for half := 0; half < 2; half++ {
+		for i := 0; i < n; i++ {
+			for j := 0; j < conns; j++ {
+				from, to := fmt.Sprint(half*n+i), fmt.Sprint(half*n+r.Intn(n))
+				if from != to && !g.LinkExists(from, to) {
+					g.AddLink(from, to)
+				}
+			}
+		}
+	}
+	g.AddLink("0", fmt.Sprint(n))
+	return g
+}
+
+func TestSplitBrain(t *testing.T) {
+	g := splitBrain(100, 3)
+	tests := []struct {
+		name   string
+		detect func(*graph.Graph, *Options) *Partition
+		minQ   float64
+	}{
+		{"Louvain", Louvain, 0.5},
+		{"LabelPropagation", LabelPropagation, 0.3},
+	}
+	for _, test := range tests {
+		p := test.detect(g, nil)
+		// no community should span both halves
+		for _, c := range p.Communities {
+			first, _ := g.NodeByID(c[0])
+			for _, id := range c {
+				if idx, _ := g.NodeByID(id); (idx < 100) != (first < 100) {
+					t.Fatalf("%s: community %v spans both halves", test.name, c)
+				}
+			}
+		}
+		if p.Modularity < test.minQ {
+			t.Fatalf("%s: expected modularity to be at least %v, but got %v", test.name, test.minQ, p.Modularity)
+		}
+	}
+}
+
+func TestSplitBrainGenerator(t *testing.T) {
+	// hemispheres are random, so only partition-independent properties are
+	// checked here; community quality is covered by TestSplitBrain
+	g := net.NewSplitBrainGenerator(200, 3, "10.0.0.0").Generate()
+	hemispheres := make(map[string]int)
+	for i, node := range g.Nodes() {
+		hemispheres[node.ID()] = i / 100
+	}
+
+	// generator links hemispheres with a single bridge between nodes 50 and 150
+	var inner [2]float64
+	for _, l := range g.Links() {
+		from, to := hemispheres[l.From()], hemispheres[l.To()]
+		if from == to {
+			inner[from]++
+			continue
+		}
+		if l.From() != "10.0.0.51" || l.To() != "10.0.0.151" {
+			t.Fatalf("Expected bridge to be the only link between halves, but got %s - %s", l.From(), l.To())
+		}
+	}
+	m := float64(len(g.Links()))
+	var expected float64
+	for _, in := range inner {
+		expected += in/m - math.Pow((2*in+1)/(2*m), 2)
+	}
+	if q := Modularity(g, hemispheres); math.Abs(q-expected) > 1e-9 {
+		t.Fatalf("Expected modularity %v, but got %v", expected, q)
+	}
+
+	p := Louvain(g, nil)
+	if len(p.Membership) != g.NumNodes() {
+		t.Fatalf("Expected %d nodes in partition, but got %d", g.NumNodes(), len(p.Membership))
+	}
+	if q := Modularity(g, p.Membership); math.Abs(q-p.Modularity) > 1e-9 || q <= 0 {
+		t.Fatalf("Expected positive modularity %v, but got %v", p.Modularity, q)
+	}
+}
+
+func TestSetGroups(t *testing.T) {
+	g := cliques()
+	p := Louvain(g, nil)
+	if n := SetGroups(g, p); n != g.NumNodes() {
+		t.Fatalf("Expected %d nodes to be updated, but got %d", g.NumNodes(), n)
+	}
+	for _, node := range g.Nodes() {
+		group := node.(graph.GroupedNode).Group()
+		if group != p.Membership[node.ID()] {
+			t.Fatalf("Expected node %s to be in group %d, but got %d", node.ID(), p.Membership[node.ID()], group)
+		}
+	}
+}
+
+func TestEmpty(t *testing.T) {
+	g := graph.NewGraph()
+	g.AddNode(graph.NewBasicNode("a"))
+	g.AddNode(graph.NewBasicNode("b"))
+	for _, p := range []*Partition{Louvain(g, nil), LabelPropagation(g, nil)} {
+		if p.Count() != 2 || p.Modularity != 0 {
+			t.Fatalf("Expected 2 singleton communities, but got %v (%v)", p.Communities, p.Modularity)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package community
+
+import "github.com/divan/graphx/graph"
+
+// LabelPropagation detects communities using asynchronous label propagation:
+// starting with unique labels, each node repeatedly adopts the label with the
+// highest total link weight among its neighbors, until every node has one of
+// the most frequent labels. Ties are broken randomly. It's much faster than
+// Louvain, but results are less stable and not optimized for modularity.
+func LabelPropagation(g *graph.Graph, opts *Options) *Partition {
+	o := opts.withDefaults()
+	w := newWeighted(g)
+
+	labels := make([]int, w.n)
+	for i := range labels {
+		labels[i] = i
+	}
+
+	counts := make([]float64, w.n)
+	marked := make([]bool, w.n)
+	var seen, best []int
+	for iter := 0; iter < o.MaxIter; iter++ {
+		var changed bool
+		for _, i := range o.Rand.Perm(w.n) {
+			if len(w.adj[i]) == 0 {
+				continue
+			}
+
+			seen = seen[:0]
+			for _, l := range w.adj[i] {
+				c := labels[l.to]
+				if !marked[c] {
+					marked[c] = true
+					seen = append(seen, c)
+				}
+				counts[c] += l.w
+			}
+
+			max := -1.0
+			best = best[:0]
+			for _, c := range seen {
+				switch {
+				case counts[c] > max:
+					max = counts[c]
+					best = append(best[:0], c)
+				case counts[c] == max:
+					best = append(best, c)
+				}
+			}
+
+			// keep current label if it's among the best ones
+			var keep bool
+			for _, c := range best {
+				if c == labels[i] {
+					keep = true
+					break
+				}
+			}
+			if !keep && len(best) > 0 {
+				labels[i] = best[o.Rand.Intn(len(best))]
+				changed = true
+			}
+
+			for _, c := range seen {
+				counts[c], marked[c] = 0, false
+			}
+		}
+		if !changed {
+			break
+		}
+	}
+	return newPartition(g, w, labels)
+}
@@ -0,0 +1,138 @@
+package community
+
+import "github.com/divan/graphx/graph"
+
+// minGain is a minimal modularity gain for the node to change its community.
+const minGain = 1e-12
+
+// Louvain detects communities using Louvain method: nodes are greedily moved
+// between neighbor communities while modularity increases, then communities
+// are aggregated into single nodes and the process is repeated on the
+// aggregated graph until no further improvement is possible.
+func Louvain(g *graph.Graph, opts *Options) *Partition {
+	o := opts.withDefaults()
+	orig := newWeighted(g)
+
+	// labels maps original nodes to the nodes of current level
+	labels := make([]int, orig.n)
+	for i := range labels {
+		labels[i] = i
+	}
+
+	w := orig
+	for {
+		comm, moved := w.moveNodes(&o)
+		if !moved {
+			break
+		}
+		comm, count := compact(comm)
+		for i := range labels {
+			labels[i] = comm[labels[i]]
+		}
+		if count == w.n {
+			break
+		}
+		w = w.aggregate(comm, count)
+	}
+	return newPartition(g, orig, labels)
+}
+
+// moveNodes performs local moving phase of Louvain method, returning
+// community of each node and whether any node has been moved.
+func (w *weighted) moveNodes(o *Options) ([]int, bool) {
+	comm := make([]int, w.n)
+	tot := make([]float64, w.n)
+	for i := range comm {
+		comm[i] = i
+		tot[i] = w.degree[i]
+	}
+	if w.total == 0 {
+		return comm, false
+	}
+
+	// weights to neighbor communities of the current node
+	neighWeight := make([]float64, w.n)
+	for i := range neighWeight {
+		neighWeight[i] = -1
+	}
+	var neighComm []int
+
+	var moved bool
+	for iter := 0; iter < o.MaxIter; iter++ {
+		var changed bool
+		for _, i := range o.Rand.Perm(w.n) {
+			ci, ki := comm[i], w.degree[i]
+
+			neighComm = append(neighComm[:0], ci)
+			neighWeight[ci] = 0
+			for _, l := range w.adj[i] {
+				c := comm[l.to]
+				if neighWeight[c] == -1 {
+					neighWeight[c] = 0
+					neighComm = append(neighComm, c)
+				}
+				neighWeight[c] += l.w
+			}
+
+			// remove node from its community and find the best one to insert
+			tot[ci] -= ki
+			best, bestGain := ci, neighWeight[ci]-tot[ci]*ki/w.total
+			for _, c := range neighComm {
+				gain := neighWeight[c] - tot[c]*ki/w.total
+				if gain > bestGain+minGain {
+					best, bestGain = c, gain
+				}
+			}
+			tot[best] += ki
+			if best != ci {
+				comm[i] = best
+				changed, moved = true, true
+			}
+
+			for _, c := range neighComm {
+				neighWeight[c] = -1
+			}
+		}
+		if !changed {
+			break
+		}
+	}
+	return comm, moved
+}
+
+// aggregate creates graph where each community is represented by a single
+// node and links between communities are merged.
+func (w *weighted) aggregate(comm []int, count int) *weighted {
+	acc := make([]map[int]float64, count)
+	self := make([]float64, count)
+	for i := 0; i < w.n; i++ {
+		ci := comm[i]
+		self[ci] += w.self[i]
+		for _, l := range w.adj[i] {
+			cj := comm[l.to]
+			if ci == cj {
+				// each internal link is visited twice
+				self[ci] += l.w
+				continue
+			}
+			addWeight(acc, ci, cj, l.w)
+		}
+	}
+	return buildWeighted(acc, self)
+}
+
+// compact relabels labels to consecutive numbers starting from zero, and
+// returns number of distinct labels.
+func compact(labels []int) ([]int, int) {
+	idx := make(map[int]int)
+	ret := make([]int, len(labels))
+	for i, l := range labels {
+		c, ok := idx[l]
+		if !ok {
+			c = len(idx)
+			idx[l] = c
+		}
+		ret[i] = c
+	}
+	return ret, len(idx)
+}
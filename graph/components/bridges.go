@@ -0,0 +1,203 @@
+package components
+
+import "github.com/divan/graphx/graph"
+
+// Bridges returns links, removal of which increases number of connected
+// components. Links direction is ignored. Multiple links between the same
+// nodes are never bridges.
+func Bridges(g *graph.Graph) []*graph.Link {
+	bridges, _ := lowlink(g)
+	return linksByIndices(g, bridges)
+}
+
+// ArticulationPoints returns IDs of the nodes, removal of which increases
+// number of connected components. Links direction is ignored.
+func ArticulationPoints(g *graph.Graph) []string {
+	_, points := lowlink(g)
+	return nodeIDs(g, points)
+}
+
+// Report summarizes graph partition vulnerabilities.
+type Report struct {
+	Components         int           // number of connected components
+	Bridges            []*graph.Link // links whose failure splits the graph
+	ArticulationPoints []string      // nodes whose failure splits the graph
+}
+
+// PartitionProne returns true if graph is already partitioned, or can be
+// partitioned by a single link or node failure.
+func (r *Report) PartitionProne() bool {
+	return r.Components > 1 || len(r.Bridges) > 0 || len(r.ArticulationPoints) > 0
+}
+
+// Analyze checks graph for partition vulnerabilities.
+func Analyze(g *graph.Graph) *Report {
+	bridges, points := lowlink(g)
+	return &Report{
+		Components:         Connected(g).Count(),
+		Bridges:            linksByIndices(g, bridges),
+		ArticulationPoints: nodeIDs(g, points),
+	}
+}
+
+// linksByIndices converts link indices into links.
+func linksByIndices(g *graph.Graph, indices []int) []*graph.Link {
+	links := g.Links()
+	ret := make([]*graph.Link, len(indices))
+	for i, idx := range indices {
+		ret[i] = links[idx]
+	}
+	return ret
+}
+
+// nodeIDs converts node indices into IDs.
+func nodeIDs(g *graph.Graph, indices []int) []string {
+	nodes := g.Nodes()
+	ret := make([]string, len(indices))
+	for i, idx := range indices {
+		ret[i] = nodes[idx].ID()
+	}
+	return ret
+}
+
+// lowlink runs iterative Hopcroft-Tarjan DFS and returns indices of bridge
+// links and articulation point nodes, in order of discovery.
+func lowlink(g *graph.Graph) (bridges []int, points []int) {
+	c := g.Freeze()
+	n := c.NumNodes()
+	multi := multiLinks(g, c)
+
+	var (
+		disc    = make([]int, n) // discovery time, 0 means not visited
+		low     = make([]int, n)
+		isPoint = make([]bool, n)
+		timer   = 1
+	)
+	type frame struct {
+		u        int
+		link     int32 // link from parent, -1 for root
+		it       int
+		children int
+	}
+	for root := 0; root < n; root++ {
+		if disc[root] != 0 {
+			continue
+		}
+		disc[root], low[root] = timer, timer
+		timer++
+		frames := []frame{{u: root, link: -1}}
+
+		for len(frames) > 0 {
+			f := &frames[len(frames)-1]
+			v, link, ok := neighborAt(c, f.u, f.it)
+			if ok {
+				f.it++
+				if link == f.link {
+					continue
+				}
+				if disc[v] == 0 {
+					f.children++
+					disc[v], low[v] = timer, timer
+					timer++
+					frames = append(frames, frame{u: v, link: link})
+				} else if disc[v] < low[f.u] {
+					low[f.u] = disc[v]
+				}
+				continue
+			}
+
+			u, link := f.u, f.link
+			children := f.children
+			frames = frames[:len(frames)-1]
+			if len(frames) == 0 {
+				if children > 1 {
+					isPoint[u] = true
+				}
+				continue
+			}
+
+			p := frames[len(frames)-1].u
+			if low[u] < low[p] {
+				low[p] = low[u]
+			}
+			if low[u] > disc[p] && !multi[pair(u, p)] {
+				bridges = append(bridges, int(link))
+			}
+			if low[u] >= disc[p] && p != root {
+				isPoint[p] = true
+			}
+		}
+	}
+
+	for i := range isPoint {
+		if isPoint[i] {
+			points = append(points, i)
+		}
+	}
+	return bridges, points
+}
+
+// neighborAt returns i-th neighbor of u and the link index, ignoring links direction.
+func neighborAt(c *graph.CSR, u, i int) (int, int32, bool) {
+	out := c.Neighbors(u)
+	if i < len(out) {
+		return int(out[i]), c.LinkIndices(u)[i], true
+	}
+	if !c.Directed() {
+		return 0, 0, false
+	}
+	i -= len(out)
+	in := c.InNeighbors(u)
+	if i < len(in) {
+		return int(in[i]), c.InLinkIndices(u)[i], true
+	}
+	return 0, 0, false
+}
+
+// multiLinks returns set of node pairs connected with more than one link,
+// as they are represented by a single link in CSR snapshot.
+func multiLinks(g *graph.Graph, c *graph.CSR) map[[2]int]bool {
+	var unique int
+	for i := 0; i < c.NumNodes(); i++ {
+		unique += c.Degree(i)
+	}
+	if !c.Directed() {
+		unique = (unique + selfLoops(c)) / 2
+	}
+	if unique == g.NumLinks() {
+		return nil
+	}
+
+	counts := make(map[[2]int]int)
+	for _, link := range g.Links() {
+		counts[pair(link.FromIdx(), link.ToIdx())]++
+	}
+	ret := make(map[[2]int]bool)
+	for key, count := range counts {
+		if count > 1 {
+			ret[key] = true
+		}
+	}
+	return ret
+}
+
+// selfLoops returns number of links from node to itself.
+func selfLoops(c *graph.CSR) int {
+	var ret int
+	for i := 0; i < c.NumNodes(); i++ {
+		for _, v := range c.Neighbors(i) {
+			if int(v) == i {
+				ret++
+			}
+		}
+	}
+	return ret
+}
+
+// pair returns key for unordered pair of node indices.
+func pair(a, b int) [2]int {
+	if a > b {
+		a, b = b, a
+	}
+	return [2]int{a, b}
+}
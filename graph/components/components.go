@@ -0,0 +1,185 @@
+// Package components implements connected components detection, as well as
+// bridges and articulation points search, which is useful for finding
+// partition-prone (split-brain) network topologies.
+package components
+
+import "github.com/divan/graphx/graph"
+
+// Components represents partition of graph nodes into components.
+// Components are ordered by the lowest index of their nodes.
+type Components struct {
+	Membership map[string]int // component index for each node ID
+	Members    [][]string     // node IDs of each component
+}
+
+// Count returns number of components.
+func (c *Components) Count() int {
+	return len(c.Members)
+}
+
+// Sizes returns size of each component.
+func (c *Components) Sizes() []int {
+	ret := make([]int, len(c.Members))
+	for i := range c.Members {
+		ret[i] = len(c.Members[i])
+	}
+	return ret
+}
+
+// Largest returns IDs of the nodes in the largest component.
+func (c *Components) Largest() []string {
+	var ret []string
+	for _, m := range c.Members {
+		if len(m) > len(ret) {
+			ret = m
+		}
+	}
+	return ret
+}
+
+// newComponents creates Components from component labels for each node index.
+func newComponents(c *graph.CSR, labels []int) *Components {
+	// relabel components in order of their lowest node index
+	order := make(map[int]int)
+	for _, l := range labels {
+		if _, ok := order[l]; !ok {
+			order[l] = len(order)
+		}
+	}
+
+	ret := &Components{
+		Membership: make(map[string]int, len(labels)),
+		Members:    make([][]string, len(order)),
+	}
+	for i, l := range labels {
+		idx := order[l]
+		ret.Membership[c.ID(i)] = idx
+		ret.Members[idx] = append(ret.Members[idx], c.ID(i))
+	}
+	return ret
+}
+
+// Connected returns connected components of the graph. For directed graphs
+// links direction is ignored, so it's the same as Weakly.
+func Connected(g *graph.Graph) *Components {
+	c := g.Freeze()
+	n := c.NumNodes()
+	labels := make([]int, n)
+	for i := range labels {
+		labels[i] = -1
+	}
+
+	var stack []int32
+	for root := 0; root < n; root++ {
+		if labels[root] != -1 {
+			continue
+		}
+		labels[root] = root
+		stack = append(stack[:0], int32(root))
+		for len(stack) > 0 {
+			u := int(stack[len(stack)-1])
+			stack = stack[:len(stack)-1]
+			forEachNeighbor(c, u, func(v, _ int32) {
+				if labels[v] == -1 {
+					labels[v] = root
+					stack = append(stack, v)
+				}
+			})
+		}
+	}
+	return newComponents(c, labels)
+}
+
+// Weakly returns weakly connected components of the directed graph, i.e.
+// components of the graph with links direction ignored.
+func Weakly(g *graph.Graph) *Components {
+	return Connected(g)
+}
+
+// Strongly returns strongly connected components of the directed graph, where
+// every node is reachable from every other node following links direction.
+// For undirected graphs it's the same as Connected.
+func Strongly(g *graph.Graph) *Components {
+	c := g.Freeze()
+	n := c.NumNodes()
+
+	// iterative Tarjan's algorithm
+	var (
+		index   = make([]int, n)
+		low     = make([]int, n)
+		onStack = make([]bool, n)
+		labels  = make([]int, n)
+		stack   []int
+		counter = 1
+	)
+	type frame struct {
+		u, it int
+	}
+	for root := 0; root < n; root++ {
+		if index[root] != 0 {
+			continue
+		}
+		frames := []frame{{u: root}}
+		index[root], low[root] = counter, counter
+		counter++
+		stack = append(stack, root)
+		onStack[root] = true
+
+		for len(frames) > 0 {
+			f := &frames[len(frames)-1]
+			neighbors := c.Neighbors(f.u)
+			if f.it < len(neighbors) {
+				v := int(neighbors[f.it])
+				f.it++
+				if index[v] == 0 {
+					index[v], low[v] = counter, counter
+					counter++
+					stack = append(stack, v)
+					onStack[v] = true
+					frames = append(frames, frame{u: v})
+				} else if onStack[v] && index[v] < low[f.u] {
+					low[f.u] = index[v]
+				}
+				continue
+			}
+
+			u := f.u
+			frames = frames[:len(frames)-1]
+			if len(frames) > 0 {
+				p := frames[len(frames)-1].u
+				if low[u] < low[p] {
+					low[p] = low[u]
+				}
+			}
+			if low[u] == index[u] {
+				// u is a root of component
+				top := len(stack) - 1
+				for stack[top] != u {
+					top--
+				}
+				for _, v := range stack[top:] {
+					onStack[v] = false
+					labels[v] = u
+				}
+				stack = stack[:top]
+			}
+		}
+	}
+
+	return newComponents(c, labels)
+}
+
+// forEachNeighbor calls fn for each neighbor of u and corresponding link index,
+// ignoring links direction.
+func forEachNeighbor(c *graph.CSR, u int, fn func(v, link int32)) {
+	links := c.LinkIndices(u)
+	for i, v := range c.Neighbors(u) {
+		fn(v, links[i])
+	}
+	if c.Directed() {
+		links = c.InLinkIndices(u)
+		for i, v := range c.InNeighbors(u) {
+			fn(v, links[i])
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package components
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/divan/graphx/generation/basic"
+	"github.com/divan/graphx/generation/net"
+	"github.com/divan/graphx/graph/internal/graphtest"
+)
+
+func TestConnected(t *testing.T) {
+	g := graphtest.New(false, []string{"a", "b", "c", "d", "e"}, [2]string{"a", "c"}, [2]string{"d", "b"})
+	c := Connected(g)
+	if c.Count() != 3 {
+		t.Fatalf("Expected %d components, but got %d", 3, c.Count())
+	}
+	expected := [][]string{{"a", "c"}, {"b", "d"}, {"e"}}
+	if !reflect.DeepEqual(c.Members, expected) {
+		t.Fatalf("Expected components %v, but got %v", expected, c.Members)
+	}
+	if !reflect.DeepEqual(c.Sizes(), []int{2, 2, 1}) {
+		t.Fatalf("Unexpected sizes: %v", c.Sizes())
+	}
+	if c.Membership["d"] != 1 {
+		t.Fatalf("Expected 'd' to be in component %d, but got %d", 1, c.Membership["d"])
+	}
+}
+
+func TestStrongly(t *testing.T) {
+	// a -> b -> c -> a, c -> d -> e -> d
+	g := graphtest.New(true, []string{"a", "b", "c", "d", "e"},
+		[2]string{"a", "b"}, [2]string{"b", "c"}, [2]string{"c", "a"},
+		[2]string{"c", "d"}, [2]string{"d", "e"}, [2]string{"e", "d"})
+	s := Strongly(g)
+	expected := [][]string{{"a", "b", "c"}, {"d", "e"}}
+	if !reflect.DeepEqual(s.Members, expected) {
+		t.Fatalf("Expected components %v, but got %v", expected, s.Members)
+	}
+	if w := Weakly(g); w.Count() != 1 {
+		t.Fatalf("Expected %d weak component, but got %d", 1, w.Count())
+	}
+}
+
+func TestBridges(t *testing.T) {
+	// two triangles connected with a-d link, plus e hanging on d
+	g := graphtest.New(false, []string{"a", "b", "c", "d", "e", "f", "g"},
+		[2]string{"a", "b"}, [2]string{"b", "c"}, [2]string{"c", "a"},
+		[2]string{"a", "d"},
+		[2]string{"d", "f"}, [2]string{"f", "g"}, [2]string{"g", "d"},
+		[2]string{"d", "e"})
+
+	bridges := Bridges(g)
+	if len(bridges) != 2 {
+		t.Fatalf("Expected %d bridges, but got %d", 2, len(bridges))
+	}
+	got := map[string]bool{}
+	for _, b := range bridges {
+		got[b.From()+"-"+b.To()] = true
+	}
+	if !got["a-d"] || !got["d-e"] {
+		t.Fatalf("Unexpected bridges: %v", got)
+	}
+
+	points := ArticulationPoints(g)
+	if !reflect.DeepEqual(points, []string{"a", "d"}) {
+		t.Fatalf("Expected articulation points [a d], but got %v", points)
+	}
+
+	// duplicate link is not a bridge anymore
+	g.AddLink("d", "e")
+	if len(Bridges(g)) != 1 {
+		t.Fatalf("Expected duplicate link not to be a bridge")
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	g := basic.NewGrid2DGenerator(4, 4).Generate()
+	if r := Analyze(g); r.PartitionProne() {
+		t.Fatalf("Expected grid not to be partition prone, but got %+v", r)
+	}
+
+	g = net.NewSplitBrainGenerator(40, 3, "10.0.0.1").Generate()
+	r := Analyze(g)
+	if !r.PartitionProne() || len(r.Bridges) == 0 {
+		t.Fatalf("Expected split-brain network to be partition prone, but got %+v", r)
+	}
+	found := false
+	for _, b := range r.Bridges {
+		if b.From() == "10.0.0.12" && b.To() == "10.0.0.32" {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("Expected link between halves to be a bridge")
+	}
+}
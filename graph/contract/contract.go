@@ -0,0 +1,159 @@
+// Package contract implements graph contraction, where groups of nodes are
+// collapsed into super-nodes, which is useful for overview-first visualization
+// of large graphs. Super-nodes can be expanded back to their members.
+package contract
+
+import (
+	"fmt"
+
+	"github.com/divan/graphx/graph"
+)
+
+// Contraction represents contracted graph with the mapping between super-nodes
+// and original nodes.
+//
+// Super-nodes are graph.BasicNode values with ID "group-N", group N and weight
+// equal to the number of members. Links between super-nodes have weight equal
+// to the number of underlying links, and links within super-nodes are dropped.
+type Contraction struct {
+	Graph   *graph.Graph
+	Members map[string][]string // member node IDs for each super-node ID
+	Super   map[string]string   // super-node ID for each original node ID
+
+	original *graph.Graph
+	order    []string       // super-node IDs in order of appearance
+	labels   map[string]int // partition label for each super-node ID
+}
+
+// ByGroup contracts nodes sharing the same group. Nodes not implementing
+// graph.GroupedNode are treated as having group 0.
+func ByGroup(g *graph.Graph) *Contraction {
+	membership := make(map[string]int, g.NumNodes())
+	for _, node := range g.Nodes() {
+		var group int
+		if gn, ok := node.(graph.GroupedNode); ok {
+			group = gn.Group()
+		}
+		membership[node.ID()] = group
+	}
+	c, _ := ByMembership(g, membership)
+	return c
+}
+
+// ByMembership contracts nodes according to the partition, given as mapping
+// from node IDs to partition labels, e.g. community.Partition.Membership or
+// components.Components.Membership. Every node must have a label.
+func ByMembership(g *graph.Graph, membership map[string]int) (*Contraction, error) {
+	c := &Contraction{
+		Members:  make(map[string][]string),
+		Super:    make(map[string]string, g.NumNodes()),
+		original: g,
+		labels:   make(map[string]int),
+	}
+	for _, node := range g.Nodes() {
+		label, ok := membership[node.ID()]
+		if !ok {
+			return nil, fmt.Errorf("node %s has no partition label", node.ID())
+		}
+		id := superID(label)
+		if _, ok := c.Members[id]; !ok {
+			c.order = append(c.order, id)
+			c.labels[id] = label
+		}
+		c.Members[id] = append(c.Members[id], node.ID())
+		c.Super[node.ID()] = id
+	}
+
+	c.Graph = c.build(nil)
+	return c, nil
+}
+
+// Expand returns graph where given super-nodes are replaced by their member
+// nodes. Original links between expanded nodes are copied, and links to the
+// remaining super-nodes are aggregated. Expanding all super-nodes gives
+// the graph equal to the original one, except for duplicate links.
+func (c *Contraction) Expand(ids ...string) (*graph.Graph, error) {
+	expanded := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if _, ok := c.Members[id]; !ok {
+			return nil, fmt.Errorf("super-node %s not found", id)
+		}
+		expanded[id] = true
+	}
+	for _, id := range ids {
+		for _, member := range c.Members[id] {
+			if _, ok := c.Members[member]; ok && !expanded[member] {
+				return nil, fmt.Errorf("node ID %s conflicts with super-node ID", member)
+			}
+		}
+	}
+	return c.build(expanded), nil
+}
+
+// build creates graph with super-nodes, except expanded ones, which are
+// represented by their original members.
+func (c *Contraction) build(expanded map[string]bool) *graph.Graph {
+	g := c.original
+	var ret *graph.Graph
+	if g.Directed() {
+		ret = graph.NewDirectedGraph()
+	} else {
+		ret = graph.NewGraph()
+	}
+
+	// resolve returns ID of the node representing original node in the result,
+	// and whether it's the original node itself
+	resolve := func(id string) (string, bool) {
+		if super := c.Super[id]; !expanded[super] {
+			return super, false
+		}
+		return id, true
+	}
+
+	for _, id := range c.order {
+		if !expanded[id] {
+			ret.AddNode(&graph.BasicNode{
+				ID_:     id,
+				Group_:  c.labels[id],
+				Weight_: len(c.Members[id]),
+			})
+			continue
+		}
+		for _, member := range c.Members[id] {
+			node, _ := g.Node(member)
+			ret.AddNode(node)
+		}
+	}
+
+	var links []*graph.Link
+	aggregated := make(map[[2]string]*graph.Link)
+	for _, link := range g.Links() {
+		from, fromOrig := resolve(link.From())
+		to, toOrig := resolve(link.To())
+		if fromOrig && toOrig {
+			links = append(links, link.Copy())
+			continue
+		}
+		if from == to {
+			continue
+		}
+		key := [2]string{from, to}
+		if !g.Directed() && from > to {
+			key = [2]string{to, from}
+		}
+		if l, ok := aggregated[key]; ok {
+			l.SetWeight(l.Weight() + 1)
+			continue
+		}
+		l := graph.NewLink(from, to)
+		aggregated[key] = l
+		links = append(links, l)
+	}
+	ret.AddLinks(links...)
+	return ret
+}
+
+// superID returns ID of the super-node for the partition label.
+func superID(label int) string {
+	return fmt.Sprintf("group-%d", label)
+}
@@ -0,0 +1,119 @@
+package contract
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/divan/graphx/graph"
+)
+
+// testGraph creates graph with groups: a, b, c in group 1, d, e in group 2
+// and f in group 3.
+func testGraph() *graph.Graph {
+	g := graph.NewGraph()
+	groups := map[string]int{"a": 1, "b": 1, "c": 1, "d": 2, "e": 2, "f": 3}
+	for _, id := range []string{"a", "b", "c", "d", "e", "f"} {
+		g.AddNode(&graph.BasicNode{ID_: id, Group_: groups[id]})
+	}
+	for _, l := range [][2]string{{"a", "b"}, {"b", "c"}, {"a", "d"}, {"c", "d"}, {"e", "b"}, {"d", "e"}, {"e", "f"}} {
+		g.AddLink(l[0], l[1])
+	}
+	return g
+}
+
+type link struct {
+	from, to string
+	weight   float64
+}
+
+func links(g *graph.Graph) []link {
+	var ret []link
+	for _, l := range g.Links() {
+		ret = append(ret, link{l.From(), l.To(), l.Weight()})
+	}
+	return ret
+}
+
+func TestByGroup(t *testing.T) {
+	g := testGraph()
+	c := ByGroup(g)
+
+	var nodes []graph.BasicNode
+	for _, node := range c.Graph.Nodes() {
+		nodes = append(nodes, *node.(*graph.BasicNode))
+	}
+	expectedNodes := []graph.BasicNode{
+		{ID_: "group-1", Group_: 1, Weight_: 3},
+		{ID_: "group-2", Group_: 2, Weight_: 2},
+		{ID_: "group-3", Group_: 3, Weight_: 1},
+	}
+	if !reflect.DeepEqual(nodes, expectedNodes) {
+		t.Fatalf("Expected nodes %v, but got %v", expectedNodes, nodes)
+	}
+
+	expectedLinks := []link{{"group-1", "group-2", 3}, {"group-2", "group-3", 1}}
+	if got := links(c.Graph); !reflect.DeepEqual(got, expectedLinks) {
+		t.Fatalf("Expected links %v, but got %v", expectedLinks, got)
+	}
+
+	if !reflect.DeepEqual(c.Members["group-2"], []string{"d", "e"}) || c.Super["c"] != "group-1" {
+		t.Fatalf("Unexpected mapping: %v, %v", c.Members, c.Super)
+	}
+}
+
+func TestExpand(t *testing.T) {
+	g := testGraph()
+	c := ByGroup(g)
+
+	e, err := c.Expand("group-2")
+	if err != nil {
+		t.Fatalf("Expand failed: %v", err)
+	}
+	var ids []string
+	for _, node := range e.Nodes() {
+		ids = append(ids, node.ID())
+	}
+	if !reflect.DeepEqual(ids, []string{"group-1", "d", "e", "group-3"}) {
+		t.Fatalf("Unexpected expanded nodes: %v", ids)
+	}
+	expectedLinks := []link{{"group-1", "d", 2}, {"e", "group-1", 1}, {"d", "e", 1}, {"e", "group-3", 1}}
+	if got := links(e); !reflect.DeepEqual(got, expectedLinks) {
+		t.Fatalf("Expected links %v, but got %v", expectedLinks, got)
+	}
+
+	// expanding everything gives the original graph
+	e, err = c.Expand("group-1", "group-2", "group-3")
+	if err != nil {
+		t.Fatalf("Expand failed: %v", err)
+	}
+	if e.NumNodes() != g.NumNodes() || !reflect.DeepEqual(links(e), links(g)) {
+		t.Fatalf("Expected original graph, but got %v", links(e))
+	}
+
+	if _, err := c.Expand("group-4"); err == nil {
+		t.Fatalf("Expected error for unknown super-node")
+	}
+}
+
+func TestByMembership(t *testing.T) {
+	g := graph.NewDirectedGraph()
+	for _, id := range []string{"a", "b", "c"} {
+		g.AddNode(graph.NewBasicNode(id))
+	}
+	g.AddLink("a", "c")
+	g.AddLink("c", "a")
+	g.AddLink("b", "c")
+
+	c, err := ByMembership(g, map[string]int{"a": 0, "b": 0, "c": 1})
+	if err != nil {
+		t.Fatalf("ByMembership failed: %v", err)
+	}
+	expectedLinks := []link{{"group-0", "group-1", 2}, {"group-1", "group-0", 1}}
+	if got := links(c.Graph); !reflect.DeepEqual(got, expectedLinks) {
+		t.Fatalf("Expected links %v, but got %v", expectedLinks, got)
+	}
+
+	if _, err := ByMembership(g, map[string]int{"a": 0}); err == nil {
+		t.Fatalf("Expected error for missing label")
+	}
+}
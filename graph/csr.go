@@ -0,0 +1,225 @@
+package graph
+
+import "sort"
+
+// CSR represents read-only graph snapshot in compressed sparse row format.
+// It stores adjacency as flat int32 arrays, which is much more compact than
+// Graph for large graphs and is faster to traverse.
+//
+// Neighbors of node with index i are stored in neighbors[offsets[i]:offsets[i+1]],
+// along with weights and indices of the corresponding links. For undirected graphs
+// each link is stored twice, once for each endpoint.
+type CSR struct {
+	ids      []string
+	directed bool
+	numLinks int
+
+	offsets   []int32
+	neighbors []int32
+	links     []int32
+	weights   []float64
+
+	// incoming links, for directed graphs only
+	inOffsets   []int32
+	inNeighbors []int32
+	inLinks     []int32
+	inWeights   []float64
+}
+
+// Freeze creates read-only CSR snapshot of the graph. Node indices
+// in the snapshot are the same as in the graph. Duplicate links between
+// the same nodes are represented by the first one.
+// Subsequent graph modifications are not reflected in the snapshot.
+func (g *TypedGraph[N]) Freeze() *CSR {
+	c := &CSR{
+		ids:      make([]string, len(g.nodes)),
+		directed: g.directed,
+		numLinks: len(g.links),
+	}
+	for i, node := range g.nodes {
+		c.ids[i] = node.ID()
+	}
+
+	// skip duplicate links, if any
+	links := g.links
+	if len(g.edges) != len(g.links) {
+		links = make([]*Link, 0, len(g.edges))
+		for i, link := range g.links {
+			if g.edges[g.edgeKey(link.fromIdx, link.toIdx)].idx == i {
+				links = append(links, link)
+			}
+		}
+	}
+
+	// count degrees
+	c.offsets = make([]int32, len(g.nodes)+1)
+	if g.directed {
+		c.inOffsets = make([]int32, len(g.nodes)+1)
+	}
+	for _, link := range links {
+		c.offsets[link.fromIdx+1]++
+		if g.directed {
+			c.inOffsets[link.toIdx+1]++
+		} else if link.fromIdx != link.toIdx {
+			c.offsets[link.toIdx+1]++
+		}
+	}
+	for i := 1; i < len(c.offsets); i++ {
+		c.offsets[i] += c.offsets[i-1]
+		if g.directed {
+			c.inOffsets[i] += c.inOffsets[i-1]
+		}
+	}
+
+	// fill neighbors in order of links addition
+	total := c.offsets[len(g.nodes)]
+	c.neighbors = make([]int32, total)
+	c.links = make([]int32, total)
+	c.weights = make([]float64, total)
+	pos := make([]int32, len(g.nodes))
+	copy(pos, c.offsets)
+	var inPos []int32
+	if g.directed {
+		inTotal := c.inOffsets[len(g.nodes)]
+		c.inNeighbors = make([]int32, inTotal)
+		c.inLinks = make([]int32, inTotal)
+		c.inWeights = make([]float64, inTotal)
+		inPos = make([]int32, len(g.nodes))
+		copy(inPos, c.inOffsets)
+	}
+	for i, link := range links {
+		idx := int32(i)
+		if len(links) != len(g.links) {
+			idx = int32(g.edges[g.edgeKey(link.fromIdx, link.toIdx)].idx)
+		}
+		from, to := link.fromIdx, link.toIdx
+		add(c.neighbors, c.links, c.weights, pos, from, to, idx, link.weight)
+		if g.directed {
+			add(c.inNeighbors, c.inLinks, c.inWeights, inPos, to, from, idx, link.weight)
+		} else if from != to {
+			add(c.neighbors, c.links, c.weights, pos, to, from, idx, link.weight)
+		}
+	}
+	return c
+}
+
+// add puts neighbor j of the node i into CSR arrays at the current position.
+func add(neighbors, links []int32, weights []float64, pos []int32, i, j int, idx int32, weight float64) {
+	p := pos[i]
+	neighbors[p] = int32(j)
+	links[p] = idx
+	weights[p] = weight
+	pos[i]++
+}
+
+// NumNodes returns total number of nodes.
+func (c *CSR) NumNodes() int {
+	return len(c.ids)
+}
+
+// NumLinks returns total number of links in the original graph.
+func (c *CSR) NumLinks() int {
+	return c.numLinks
+}
+
+// Directed returns true if snapshot was made from directed graph.
+func (c *CSR) Directed() bool {
+	return c.directed
+}
+
+// ID returns node ID by its index.
+func (c *CSR) ID(idx int) string {
+	return c.ids[idx]
+}
+
+// IDs returns node IDs in index order.
+func (c *CSR) IDs() []string {
+	return c.ids
+}
+
+// Degree returns number of neighbors of the node (outgoing for directed graphs).
+func (c *CSR) Degree(idx int) int {
+	return int(c.offsets[idx+1] - c.offsets[idx])
+}
+
+// Neighbors returns indices of the node neighbors (outgoing for directed graphs).
+// Returned slice must not be modified.
+func (c *CSR) Neighbors(idx int) []int32 {
+	return c.neighbors[c.offsets[idx]:c.offsets[idx+1]]
+}
+
+// Weights returns weights of the links to the node neighbors, in the same
+// order as Neighbors. Returned slice must not be modified.
+func (c *CSR) Weights(idx int) []float64 {
+	return c.weights[c.offsets[idx]:c.offsets[idx+1]]
+}
+
+// LinkIndices returns indices of the original graph links to the node neighbors,
+// in the same order as Neighbors. Returned slice must not be modified.
+func (c *CSR) LinkIndices(idx int) []int32 {
+	return c.links[c.offsets[idx]:c.offsets[idx+1]]
+}
+
+// InDegree returns number of incoming neighbors of the node. For undirected
+// graphs it's the same as Degree.
+func (c *CSR) InDegree(idx int) int {
+	if !c.directed {
+		return c.Degree(idx)
+	}
+	return int(c.inOffsets[idx+1] - c.inOffsets[idx])
+}
+
+// InNeighbors returns indices of the nodes having links to the node. For undirected
+// graphs it's the same as Neighbors. Returned slice must not be modified.
+func (c *CSR) InNeighbors(idx int) []int32 {
+	if !c.directed {
+		return c.Neighbors(idx)
+	}
+	return c.inNeighbors[c.inOffsets[idx]:c.inOffsets[idx+1]]
+}
+
+// InWeights returns weights of the incoming links, in the same order as InNeighbors.
+// Returned slice must not be modified.
+func (c *CSR) InWeights(idx int) []float64 {
+	if !c.directed {
+		return c.Weights(idx)
+	}
+	return c.inWeights[c.inOffsets[idx]:c.inOffsets[idx+1]]
+}
+
+// InLinkIndices returns indices of the original graph incoming links, in the same
+// order as InNeighbors. Returned slice must not be modified.
+func (c *CSR) InLinkIndices(idx int) []int32 {
+	if !c.directed {
+		return c.LinkIndices(idx)
+	}
+	return c.inLinks[c.inOffsets[idx]:c.inOffsets[idx+1]]
+}
+
+// UndirectedNeighbors returns sorted neighbor indices for each node, ignoring
+// links direction, without duplicates and self loops. It's useful for
+// algorithms defined on simple undirected graphs.
+func (c *CSR) UndirectedNeighbors() [][]int32 {
+	n := c.NumNodes()
+	ret := make([][]int32, n)
+	for i := 0; i < n; i++ {
+		nb := make([]int32, 0, c.Degree(i))
+		nb = append(nb, c.Neighbors(i)...)
+		if c.directed {
+			nb = append(nb, c.InNeighbors(i)...)
+		}
+		sort.Slice(nb, func(a, b int) bool { return nb[a] < nb[b] })
+
+		// remove duplicates and self loops in place
+		k := 0
+		for j, v := range nb {
+			if int(v) == i || (j > 0 && v == nb[j-1]) {
+				continue
+			}
+			nb[k] = v
+			k++
+		}
+		ret[i] = nb[:k]
+	}
+	return ret
+}
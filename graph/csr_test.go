@@ -0,0 +1,100 @@
+package graph_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/divan/graphx/formats"
+	"github.com/divan/graphx/graph"
+)
+
+const benchData = "../formats/testdata/net10k.json"
+
+func BenchmarkFreeze(b *testing.B) {
+	g, err := formats.FromD3JSON(benchData)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		g.Freeze()
+	}
+}
+
+// BenchmarkNeighbors compares iterating over all nodes' neighbors using
+// Graph links, Graph adjacency and CSR snapshot.
+func BenchmarkNeighbors(b *testing.B) {
+	g, err := formats.FromD3JSON(benchData)
+	if err != nil {
+		b.Fatal(err)
+	}
+	c := g.Freeze()
+
+	b.Run("Links", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			sum := 0
+			for _, link := range g.Links() {
+				from, _ := g.NodeByID(link.From())
+				to, _ := g.NodeByID(link.To())
+				sum += from + to
+			}
+		}
+	})
+	b.Run("Graph", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			sum := 0
+			for idx := 0; idx < g.NumNodes(); idx++ {
+				for _, j := range g.NeighborIndices(idx) {
+					sum += j
+				}
+			}
+		}
+	})
+	b.Run("CSR", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			sum := 0
+			for idx := 0; idx < c.NumNodes(); idx++ {
+				for _, j := range c.Neighbors(idx) {
+					sum += int(j)
+				}
+			}
+		}
+	})
+}
+
+func TestFreezeNet10k(t *testing.T) {
+	g, err := formats.FromD3JSON(benchData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := g.Freeze()
+
+	if c.NumNodes() != g.NumNodes() || c.NumLinks() != g.NumLinks() {
+		t.Fatalf("Expected snapshot to have the same size as graph")
+	}
+	for i := 0; i < c.NumNodes(); i++ {
+		id := g.Nodes()[i].ID()
+		if c.ID(i) != id || c.Degree(i) != len(g.NeighborIndices(i)) {
+			t.Fatalf("Node %s: snapshot doesn't match graph", id)
+		}
+	}
+}
+
+func TestUndirectedNeighbors(t *testing.T) {
+	g := graph.NewDirectedGraph()
+	for _, id := range []string{"a", "b", "c"} {
+		g.AddNode(graph.NewBasicNode(id))
+	}
+	g.AddLink("c", "a")
+	g.AddLink("a", "c")
+	g.AddLink("a", "b")
+	g.AddLink("b", "b")
+
+	got := g.Freeze().UndirectedNeighbors()
+	expected := [][]int32{{1, 2}, {0}, {0}}
+	if !reflect.DeepEqual(got, expected) {
+		t.Fatalf("Expected neighbors %v, but got %v", expected, got)
+	}
+}
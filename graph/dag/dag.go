@@ -0,0 +1,170 @@
+// Package dag implements cycle detection and utilities for directed acyclic
+// graphs: topological sort, layering, longest path and transitive reduction.
+package dag
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/divan/graphx/graph"
+)
+
+// ErrUndirected is returned when DAG algorithm is applied to undirected graph.
+var ErrUndirected = errors.New("graph is undirected")
+
+// ErrCycle is matched by CycleError with errors.Is.
+var ErrCycle = errors.New("graph has cycle")
+
+// CycleError is returned when graph is expected to be acyclic, but has cycle.
+type CycleError struct {
+	Cycle []string // node IDs of the cycle, see FindCycle
+}
+
+// Error implements error interface.
+func (e *CycleError) Error() string {
+	path := make([]string, 0, len(e.Cycle)+1)
+	path = append(path, e.Cycle...)
+	if len(e.Cycle) > 0 {
+		path = append(path, e.Cycle[0])
+	}
+	return fmt.Sprintf("%v: %s", ErrCycle, strings.Join(path, " -> "))
+}
+
+// Unwrap returns ErrCycle.
+func (e *CycleError) Unwrap() error { return ErrCycle }
+
+// FindCycle returns any cycle of the graph as a list of node IDs, where each
+// node is linked to the next one, and the last node is linked to the first one.
+// It returns nil if graph is acyclic. For undirected graphs cycles have at least
+// three nodes, unless there are self loops or duplicate links.
+func FindCycle(g *graph.Graph) []string {
+	if g.Directed() {
+		return findDirected(g.Freeze())
+	}
+	return findUndirected(g)
+}
+
+// node colors for DFS.
+const (
+	white = iota // not visited
+	gray         // on the stack
+	black        // finished
+)
+
+// findDirected finds cycle using iterative DFS, where link to a gray node
+// closes the cycle.
+func findDirected(c *graph.CSR) []string {
+	n := c.NumNodes()
+	color := make([]int, n)
+	parent := make([]int, n)
+	next := make([]int, n) // next neighbor position to visit
+
+	for root := 0; root < n; root++ {
+		if color[root] != white {
+			continue
+		}
+		color[root] = gray
+		parent[root] = -1
+		stack := []int{root}
+		for len(stack) > 0 {
+			u := stack[len(stack)-1]
+			neighbors := c.Neighbors(u)
+			if next[u] == len(neighbors) {
+				color[u] = black
+				stack = stack[:len(stack)-1]
+				continue
+			}
+			v := int(neighbors[next[u]])
+			next[u]++
+			switch color[v] {
+			case white:
+				color[v] = gray
+				parent[v] = u
+				stack = append(stack, v)
+			case gray:
+				return cyclePath(c, parent, u, v)
+			}
+		}
+	}
+	return nil
+}
+
+// findUndirected finds cycle using union-find over links: the first link
+// connecting already connected nodes closes the cycle.
+func findUndirected(g *graph.Graph) []string {
+	c := g.Freeze()
+	n := g.NumNodes()
+	root := make([]int, n)
+	for i := range root {
+		root[i] = i
+	}
+	find := func(x int) int {
+		for root[x] != x {
+			root[x] = root[root[x]]
+			x = root[x]
+		}
+		return x
+	}
+
+	// tree built from links seen so far, to extract the cycle path
+	tree := make([][]int, n)
+	for _, link := range g.Links() {
+		u, v := link.FromIdx(), link.ToIdx()
+		if u == v {
+			return []string{c.ID(u)}
+		}
+		ru, rv := find(u), find(v)
+		if ru == rv {
+			return treePath(c, tree, u, v)
+		}
+		root[ru] = rv
+		tree[u] = append(tree[u], v)
+		tree[v] = append(tree[v], u)
+	}
+	return nil
+}
+
+// treePath returns IDs of the nodes on the path from u to v in the forest.
+func treePath(c *graph.CSR, tree [][]int, u, v int) []string {
+	parent := make(map[int]int, len(tree))
+	parent[u] = -1
+	queue := []int{u}
+	for head := 0; head < len(queue); head++ {
+		x := queue[head]
+		if x == v {
+			break
+		}
+		for _, y := range tree[x] {
+			if _, ok := parent[y]; !ok {
+				parent[y] = x
+				queue = append(queue, y)
+			}
+		}
+	}
+
+	var ret []string
+	for x := v; x != -1; x = parent[x] {
+		ret = append(ret, c.ID(x))
+	}
+	reverse(ret)
+	return ret
+}
+
+// cyclePath returns IDs of the nodes on the DFS tree path from v to u,
+// closed by link u -> v.
+func cyclePath(c *graph.CSR, parent []int, u, v int) []string {
+	var ret []string
+	for x := u; x != v; x = parent[x] {
+		ret = append(ret, c.ID(x))
+	}
+	ret = append(ret, c.ID(v))
+	reverse(ret)
+	return ret
+}
+
+func reverse(s []string) {
+	for i, j := 0, len(s)-1; i < j; i, j = i+1, j-1 {
+		s[i], s[j] = s[j], s[i]
+	}
+}
@@ -0,0 +1,144 @@
+package dag
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/divan/graphx/generation/basic"
+	"github.com/divan/graphx/graph"
+	"github.com/divan/graphx/graph/internal/graphtest"
+)
+
+// services creates dependency graph:
+//
+//	api -> auth -> db, api -> cache -> db, api -> db, worker -> db
+func services() *graph.Graph {
+	return graphtest.New(true, []string{"worker", "db", "auth", "cache", "api"},
+		[2]string{"api", "auth"}, [2]string{"auth", "db"}, [2]string{"api", "cache"},
+		[2]string{"cache", "db"}, [2]string{"api", "db"}, [2]string{"worker", "db"})
+}
+
+// checkCycle checks that nodes form a cycle in g.
+func checkCycle(t *testing.T, g *graph.Graph, cycle []string) {
+	t.Helper()
+	if len(cycle) == 0 {
+		t.Fatalf("Expected cycle, but got none")
+	}
+	for i := range cycle {
+		from, to := cycle[i], cycle[(i+1)%len(cycle)]
+		if !g.LinkExists(from, to) {
+			t.Fatalf("Cycle %v is broken: no link %s -> %s", cycle, from, to)
+		}
+	}
+}
+
+func TestFindCycle(t *testing.T) {
+	if cycle := FindCycle(services()); cycle != nil {
+		t.Fatalf("Expected no cycle, but got %v", cycle)
+	}
+
+	g := services()
+	g.AddLink("db", "api")
+	cycle := FindCycle(g)
+	checkCycle(t, g, cycle)
+	if len(cycle) != 3 {
+		t.Fatalf("Expected cycle of %d nodes, but got %v", 3, cycle)
+	}
+
+	g = graphtest.New(true, []string{"a"}, [2]string{"a", "a"})
+	if cycle := FindCycle(g); !reflect.DeepEqual(cycle, []string{"a"}) {
+		t.Fatalf("Expected self loop, but got %v", cycle)
+	}
+
+	grid := basic.NewGrid2DGenerator(3, 3).Generate()
+	checkCycle(t, grid, FindCycle(grid))
+	line := basic.NewLineGenerator(5).Generate()
+	if cycle := FindCycle(line); cycle != nil {
+		t.Fatalf("Expected no cycle, but got %v", cycle)
+	}
+}
+
+func TestTopologicalSort(t *testing.T) {
+	order, err := TopologicalSort(services())
+	if err != nil {
+		t.Fatalf("TopologicalSort failed: %v", err)
+	}
+	expected := []string{"worker", "api", "auth", "cache", "db"}
+	if !reflect.DeepEqual(order, expected) {
+		t.Fatalf("Expected order %v, but got %v", expected, order)
+	}
+
+	g := services()
+	g.AddLink("db", "auth")
+	_, err = TopologicalSort(g)
+	var cerr *CycleError
+	if !errors.Is(err, ErrCycle) || !errors.As(err, &cerr) {
+		t.Fatalf("Expected cycle error, but got %v", err)
+	}
+	checkCycle(t, g, cerr.Cycle)
+
+	if _, err := TopologicalSort(graph.NewGraph()); err != ErrUndirected {
+		t.Fatalf("Expected %v, but got %v", ErrUndirected, err)
+	}
+}
+
+func TestLayers(t *testing.T) {
+	layers, err := Layers(services())
+	if err != nil {
+		t.Fatalf("Layers failed: %v", err)
+	}
+	expected := [][]string{{"worker", "api"}, {"auth", "cache"}, {"db"}}
+	if !reflect.DeepEqual(layers, expected) {
+		t.Fatalf("Expected layers %v, but got %v", expected, layers)
+	}
+}
+
+func TestLongestPath(t *testing.T) {
+	path, length, err := LongestPath(services())
+	if err != nil {
+		t.Fatalf("LongestPath failed: %v", err)
+	}
+	if !reflect.DeepEqual(path, []string{"api", "auth", "db"}) || length != 2 {
+		t.Fatalf("Unexpected longest path %v (%v)", path, length)
+	}
+
+	// weighted: direct link is the longest
+	g := services()
+	l, _ := g.LinkIndex("api", "db")
+	g.Links()[l].SetWeight(5)
+	path, length, _ = LongestPath(g)
+	if !reflect.DeepEqual(path, []string{"api", "db"}) || length != 5 {
+		t.Fatalf("Unexpected longest path %v (%v)", path, length)
+	}
+
+	// duplicate links: the heaviest one is used
+	g = graphtest.New(true, []string{"a", "b"})
+	g.AddWeightedLink("a", "b", 1)
+	g.AddWeightedLink("a", "b", 5)
+	path, length, _ = LongestPath(g)
+	if !reflect.DeepEqual(path, []string{"a", "b"}) || length != 5 {
+		t.Fatalf("Unexpected longest path %v (%v)", path, length)
+	}
+}
+
+func TestTransitiveReduction(t *testing.T) {
+	g := services()
+	g.AddLink("auth", "db") // duplicate
+	r, err := TransitiveReduction(g)
+	if err != nil {
+		t.Fatalf("TransitiveReduction failed: %v", err)
+	}
+	if r.NumNodes() != g.NumNodes() || r.NumLinks() != 5 {
+		t.Fatalf("Expected %d links, but got %v", 5, r.Links())
+	}
+	if r.LinkExists("api", "db") {
+		t.Fatalf("Expected redundant link api -> db to be removed")
+	}
+	// reachability is preserved
+	for _, l := range g.Links() {
+		if !r.LinkExists(l.From(), l.To()) && l.From() != "api" {
+			t.Fatalf("Unexpected removed link %s -> %s", l.From(), l.To())
+		}
+	}
+}
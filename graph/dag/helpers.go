@@ -0,0 +1,36 @@
+package dag
+
+import "container/heap"
+
+// bitset implements fixed size set of node indices.
+type bitset []uint64
+
+func newBitset(n int) bitset    { return make(bitset, (n+63)/64) }
+func (b bitset) set(i int)      { b[i/64] |= 1 << (uint(i) % 64) }
+func (b bitset) has(i int) bool { return b[i/64]&(1<<(uint(i)%64)) != 0 }
+func (b bitset) or(other bitset) {
+	for i := range b {
+		b[i] |= other[i]
+	}
+}
+
+// intHeap implements min-heap of node indices.
+type intHeap struct{ items ints }
+
+func (h *intHeap) push(x int) { heap.Push(&h.items, x) }
+func (h *intHeap) pop() int   { return heap.Pop(&h.items).(int) }
+func (h *intHeap) len() int   { return len(h.items) }
+
+// ints implements heap.Interface.
+type ints []int
+
+func (s ints) Len() int            { return len(s) }
+func (s ints) Less(i, j int) bool  { return s[i] < s[j] }
+func (s ints) Swap(i, j int)       { s[i], s[j] = s[j], s[i] }
+func (s *ints) Push(x interface{}) { *s = append(*s, x.(int)) }
+func (s *ints) Pop() interface{} {
+	old := *s
+	x := old[len(old)-1]
+	*s = old[:len(old)-1]
+	return x
+}
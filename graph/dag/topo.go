@@ -0,0 +1,173 @@
+package dag
+
+import (
+	"math"
+
+	"github.com/divan/graphx/graph"
+)
+
+// TopologicalSort returns node IDs in topological order, i.e. each link goes
+// from earlier node to later one. Among available nodes, ones with lower index
+// go first. It returns *CycleError if graph has cycle.
+func TopologicalSort(g *graph.Graph) ([]string, error) {
+	c, order, err := topoOrder(g)
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]string, len(order))
+	for i, idx := range order {
+		ret[i] = c.ID(idx)
+	}
+	return ret, nil
+}
+
+// Layers splits nodes into layers, where each node is placed into the layer
+// following the latest layer of its predecessors (longest path layering).
+// Sources are in the first layer, and all links go from lower layers to higher
+// ones, which is suitable for layered layouts.
+func Layers(g *graph.Graph) ([][]string, error) {
+	c, order, err := topoOrder(g)
+	if err != nil {
+		return nil, err
+	}
+
+	layer := make([]int, c.NumNodes())
+	var ret [][]string
+	for _, u := range order {
+		for _, v := range c.InNeighbors(u) {
+			if layer[v]+1 > layer[u] {
+				layer[u] = layer[v] + 1
+			}
+		}
+		for len(ret) <= layer[u] {
+			ret = append(ret, nil)
+		}
+		ret[layer[u]] = append(ret[layer[u]], c.ID(u))
+	}
+	return ret, nil
+}
+
+// LongestPath returns the longest path in the DAG, with path length
+// being the sum of its link weights, together with its length. For duplicate
+// links the heaviest one is used.
+func LongestPath(g *graph.Graph) ([]string, float64, error) {
+	c, order, err := topoOrder(g)
+	if err != nil {
+		return nil, 0, err
+	}
+	n := c.NumNodes()
+	if n == 0 {
+		return nil, 0, nil
+	}
+
+	// outgoing links of each node, including duplicate links, as the frozen
+	// graph keeps only the first of them
+	links := g.Links()
+	out := make([][]*graph.Link, n)
+	for _, link := range links {
+		out[link.FromIdx()] = append(out[link.FromIdx()], link)
+	}
+
+	dist := make([]float64, n)
+	prev := make([]int, n)
+	for i := range prev {
+		prev[i] = -1
+	}
+	for _, u := range order {
+		for _, link := range out[u] {
+			v := link.ToIdx()
+			if d := dist[u] + link.Weight(); prev[v] == -1 || d > dist[v] {
+				dist[v], prev[v] = d, u
+			}
+		}
+	}
+
+	end, best := 0, math.Inf(-1)
+	for _, u := range order {
+		if dist[u] > best {
+			end, best = u, dist[u]
+		}
+	}
+	var path []string
+	for u := end; u != -1; u = prev[u] {
+		path = append(path, c.ID(u))
+	}
+	reverse(path)
+	return path, best, nil
+}
+
+// TransitiveReduction returns graph with all nodes and minimal subset of links
+// preserving reachability: link u -> v is removed if v is reachable from u
+// through another path. Duplicate links are removed as well. It uses O(N^2)
+// bits of memory.
+func TransitiveReduction(g *graph.Graph) (*graph.Graph, error) {
+	c, order, err := topoOrder(g)
+	if err != nil {
+		return nil, err
+	}
+	n := c.NumNodes()
+
+	// reach[u] holds nodes reachable from u via paths of length at least one,
+	// computed in reverse topological order
+	reach := make([]bitset, n)
+	for i := len(order) - 1; i >= 0; i-- {
+		u := order[i]
+		reach[u] = newBitset(n)
+		for _, v := range c.Neighbors(u) {
+			reach[u].set(int(v))
+			reach[u].or(reach[v])
+		}
+	}
+
+	ret := graph.NewDirectedGraphMN(n, 0)
+	ret.AddNodes(g.Nodes()...)
+	links := g.Links()
+	for u := 0; u < n; u++ {
+		// nodes reachable through children
+		indirect := newBitset(n)
+		for _, v := range c.Neighbors(u) {
+			indirect.or(reach[v])
+		}
+		indices := c.LinkIndices(u)
+		for i, v := range c.Neighbors(u) {
+			if !indirect.has(int(v)) {
+				ret.AddLinks(links[indices[i]].Copy())
+			}
+		}
+	}
+	return ret, nil
+}
+
+// topoOrder returns node indices in topological order using Kahn's algorithm.
+func topoOrder(g *graph.Graph) (*graph.CSR, []int, error) {
+	if !g.Directed() {
+		return nil, nil, ErrUndirected
+	}
+	c := g.Freeze()
+	n := c.NumNodes()
+
+	indeg := make([]int, n)
+	h := &intHeap{}
+	for u := 0; u < n; u++ {
+		indeg[u] = c.InDegree(u)
+		if indeg[u] == 0 {
+			h.push(u)
+		}
+	}
+
+	order := make([]int, 0, n)
+	for h.len() > 0 {
+		u := h.pop()
+		order = append(order, u)
+		for _, v := range c.Neighbors(u) {
+			indeg[v]--
+			if indeg[v] == 0 {
+				h.push(int(v))
+			}
+		}
+	}
+	if len(order) < n {
+		return nil, nil, &CycleError{Cycle: findDirected(c)}
+	}
+	return c, order, nil
+}
@@ -0,0 +1,277 @@
+// Package diff implements structural comparison of graphs. Diff holds added
+// and removed nodes and links, as well as changed node fields, can be
+// serialized as JSON and applied as a patch to another graph.
+//
+// Nodes are compared by their IDs and JSON representation, so any node field
+// except ID (group, weight and attributes for BasicNode) is tracked. Links are
+// compared by their endpoints, ignoring order for undirected graphs.
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/divan/graphx/graph"
+)
+
+// Diff represents structural difference between two graphs.
+type Diff struct {
+	AddedNodes   []graph.Node  `json:"added_nodes,omitempty"`
+	RemovedNodes []string      `json:"removed_nodes,omitempty"`
+	ChangedNodes []*NodeChange `json:"changed_nodes,omitempty"`
+	AddedLinks   []*graph.Link `json:"added_links,omitempty"`
+	RemovedLinks []*LinkRef    `json:"removed_links,omitempty"`
+}
+
+// NodeChange represents changed fields of the node.
+type NodeChange struct {
+	ID    string                 `json:"id"`
+	Set   map[string]interface{} `json:"set,omitempty"`   // added or changed fields with new values
+	Unset []string               `json:"unset,omitempty"` // removed fields
+}
+
+// LinkRef identifies link by its endpoints.
+type LinkRef struct {
+	Source string `json:"source"`
+	Target string `json:"target"`
+}
+
+// Empty returns true if there are no differences.
+func (d *Diff) Empty() bool {
+	return len(d.AddedNodes) == 0 && len(d.RemovedNodes) == 0 && len(d.ChangedNodes) == 0 &&
+		len(d.AddedLinks) == 0 && len(d.RemovedLinks) == 0
+}
+
+// UnmarshalJSON implements json.Unmarshaler for Diff. Added nodes are decoded
+// as graph.BasicNode.
+func (d *Diff) UnmarshalJSON(data []byte) error {
+	type diff Diff // prevent recursion
+	var v struct {
+		diff
+		AddedNodes []*graph.BasicNode `json:"added_nodes,omitempty"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*d = Diff(v.diff)
+	d.AddedNodes = nil
+	for _, node := range v.AddedNodes {
+		d.AddedNodes = append(d.AddedNodes, node)
+	}
+	return nil
+}
+
+// Compare returns difference between graphs a and b, i.e. changes needed
+// to transform a into b.
+func Compare(a, b *graph.Graph) (*Diff, error) {
+	if a.Directed() != b.Directed() {
+		return nil, graph.ErrDirectionMismatch
+	}
+
+	d := &Diff{}
+	for _, node := range b.Nodes() {
+		if _, err := a.NodeByID(node.ID()); err != nil {
+			d.AddedNodes = append(d.AddedNodes, node)
+		}
+	}
+	for _, node := range a.Nodes() {
+		other, err := b.Node(node.ID())
+		if err != nil {
+			d.RemovedNodes = append(d.RemovedNodes, node.ID())
+			continue
+		}
+		change, err := compareNodes(node, other)
+		if err != nil {
+			return nil, err
+		}
+		if change != nil {
+			d.ChangedNodes = append(d.ChangedNodes, change)
+		}
+	}
+
+	// duplicate links are reported once
+	seen := make(map[[2]string]bool)
+	for _, link := range b.Links() {
+		key := linkKey(link.From(), link.To(), b.Directed())
+		if !a.LinkExists(link.From(), link.To()) && !seen[key] {
+			seen[key] = true
+			d.AddedLinks = append(d.AddedLinks, link)
+		}
+	}
+	for _, link := range a.Links() {
+		key := linkKey(link.From(), link.To(), a.Directed())
+		if !b.LinkExists(link.From(), link.To()) && !seen[key] {
+			seen[key] = true
+			d.RemovedLinks = append(d.RemovedLinks, &LinkRef{Source: link.From(), Target: link.To()})
+		}
+	}
+	return d, nil
+}
+
+// Apply applies diff as a patch to the graph. Changed nodes are replaced
+// with graph.BasicNode having updated fields. Diff is validated before any
+// modification, so graph is left unmodified on error.
+func Apply(g *graph.Graph, d *Diff) error {
+	updated, err := validate(g, d)
+	if err != nil {
+		return err
+	}
+
+	for _, ref := range d.RemovedLinks {
+		for g.LinkExists(ref.Source, ref.Target) {
+			if err := g.RemoveLink(ref.Source, ref.Target); err != nil {
+				return err
+			}
+		}
+	}
+	if err := g.RemoveNodes(d.RemovedNodes...); err != nil {
+		return err
+	}
+	g.AddNodes(d.AddedNodes...)
+	for _, node := range updated {
+		if err := g.ReplaceNode(node); err != nil {
+			return err
+		}
+	}
+	for _, link := range d.AddedLinks {
+		if err := g.AddLinks(link.Copy()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validate checks that diff can be applied to the graph and returns
+// updated versions of changed nodes.
+func validate(g *graph.Graph, d *Diff) ([]graph.Node, error) {
+	removed := make(map[string]bool, len(d.RemovedNodes))
+	for _, id := range d.RemovedNodes {
+		if _, err := g.NodeByID(id); err != nil {
+			return nil, err
+		}
+		removed[id] = true
+	}
+	for _, ref := range d.RemovedLinks {
+		if !g.LinkExists(ref.Source, ref.Target) {
+			return nil, fmt.Errorf("link %s->%s not found", ref.Source, ref.Target)
+		}
+	}
+
+	// nodes existing after the patch
+	exists := func(id string) bool {
+		_, err := g.NodeByID(id)
+		return err == nil && !removed[id]
+	}
+	added := make(map[string]bool, len(d.AddedNodes))
+	for _, node := range d.AddedNodes {
+		if exists(node.ID()) || added[node.ID()] {
+			return nil, fmt.Errorf("node %s already exists", node.ID())
+		}
+		added[node.ID()] = true
+	}
+	updated := make([]graph.Node, 0, len(d.ChangedNodes))
+	for _, change := range d.ChangedNodes {
+		if !exists(change.ID) {
+			return nil, fmt.Errorf("node %s not found", change.ID)
+		}
+		node, err := g.Node(change.ID)
+		if err != nil {
+			return nil, err
+		}
+		node, err = applyChange(node, change)
+		if err != nil {
+			return nil, err
+		}
+		updated = append(updated, node)
+	}
+	for _, link := range d.AddedLinks {
+		for _, id := range []string{link.From(), link.To()} {
+			if !exists(id) && !added[id] {
+				return nil, fmt.Errorf("node %s not found", id)
+			}
+		}
+	}
+	return updated, nil
+}
+
+// compareNodes returns changes of node fields, or nil if nodes are equal.
+func compareNodes(a, b graph.Node) (*NodeChange, error) {
+	fa, err := nodeFields(a)
+	if err != nil {
+		return nil, err
+	}
+	fb, err := nodeFields(b)
+	if err != nil {
+		return nil, err
+	}
+
+	change := &NodeChange{ID: a.ID()}
+	for k, v := range fb {
+		if old, ok := fa[k]; !ok || !reflect.DeepEqual(old, v) {
+			if change.Set == nil {
+				change.Set = make(map[string]interface{})
+			}
+			change.Set[k] = v
+		}
+	}
+	for k := range fa {
+		if _, ok := fb[k]; !ok {
+			change.Unset = append(change.Unset, k)
+		}
+	}
+	if change.Set == nil && change.Unset == nil {
+		return nil, nil
+	}
+	sort.Strings(change.Unset)
+	return change, nil
+}
+
+// applyChange returns BasicNode with the node fields updated.
+func applyChange(node graph.Node, change *NodeChange) (graph.Node, error) {
+	fields, err := nodeFields(node)
+	if err != nil {
+		return nil, err
+	}
+	for _, k := range change.Unset {
+		delete(fields, k)
+	}
+	for k, v := range change.Set {
+		fields[k] = v
+	}
+	fields["id"] = node.ID()
+
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+	var ret graph.BasicNode
+	if err := json.Unmarshal(data, &ret); err != nil {
+		return nil, err
+	}
+	return &ret, nil
+}
+
+// nodeFields returns JSON fields of the node, except ID.
+func nodeFields(node graph.Node) (map[string]interface{}, error) {
+	data, err := json.Marshal(node)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, fmt.Errorf("node %s: %w", node.ID(), err)
+	}
+	delete(fields, "id")
+	return fields, nil
+}
+
+// linkKey returns key of the link endpoints. For undirected graphs the order
+// of endpoints doesn't matter.
+func linkKey(from, to string, directed bool) [2]string {
+	if !directed && from > to {
+		return [2]string{to, from}
+	}
+	return [2]string{from, to}
+}
@@ -0,0 +1,122 @@
+package diff
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/divan/graphx/graph"
+)
+
+// testGraphs creates two snapshots of the network:
+//
+//	a: a-b, b-c, c-d, with a.group=1 and b.role=db
+//	b: a-b, c-b, b-e, with a.group=2 and c.role=web, d removed, e added
+func testGraphs() (*graph.Graph, *graph.Graph) {
+	a := graph.NewGraph()
+	a.AddNode(&graph.BasicNode{ID_: "a", Group_: 1})
+	b := graph.NewBasicNode("b")
+	b.SetAttr("role", "db")
+	a.AddNodes(b, graph.NewBasicNode("c"), graph.NewBasicNode("d"))
+	a.AddLink("a", "b")
+	a.AddLink("b", "c")
+	a.AddLink("c", "d")
+
+	g := graph.NewGraph()
+	g.AddNode(&graph.BasicNode{ID_: "a", Group_: 2})
+	c := graph.NewBasicNode("c")
+	c.SetAttr("role", "web")
+	g.AddNodes(graph.NewBasicNode("b"), c, graph.NewBasicNode("e"))
+	g.AddLink("a", "b")
+	g.AddLink("c", "b")
+	g.AddLink("b", "e")
+	return a, g
+}
+
+func TestCompare(t *testing.T) {
+	a, b := testGraphs()
+	d, err := Compare(a, b)
+	if err != nil {
+		t.Fatalf("Compare failed: %v", err)
+	}
+
+	if len(d.AddedNodes) != 1 || d.AddedNodes[0].ID() != "e" {
+		t.Fatalf("Unexpected added nodes: %v", d.AddedNodes)
+	}
+	if !reflect.DeepEqual(d.RemovedNodes, []string{"d"}) {
+		t.Fatalf("Unexpected removed nodes: %v", d.RemovedNodes)
+	}
+	expected := []*NodeChange{
+		{ID: "a", Set: map[string]interface{}{"group": 2.0}},
+		{ID: "b", Unset: []string{"role"}},
+		{ID: "c", Set: map[string]interface{}{"role": "web"}},
+	}
+	if !reflect.DeepEqual(d.ChangedNodes, expected) {
+		t.Fatalf("Unexpected changed nodes: %+v", d.ChangedNodes)
+	}
+	if len(d.AddedLinks) != 1 || d.AddedLinks[0].From() != "b" || d.AddedLinks[0].To() != "e" {
+		t.Fatalf("Unexpected added links: %v", d.AddedLinks)
+	}
+	if !reflect.DeepEqual(d.RemovedLinks, []*LinkRef{{Source: "c", Target: "d"}}) {
+		t.Fatalf("Unexpected removed links: %v", d.RemovedLinks)
+	}
+
+	d, _ = Compare(a, a)
+	if !d.Empty() {
+		t.Fatalf("Expected empty diff, but got %+v", d)
+	}
+}
+
+func TestApply(t *testing.T) {
+	a, b := testGraphs()
+	d, err := Compare(a, b)
+	if err != nil {
+		t.Fatalf("Compare failed: %v", err)
+	}
+
+	// patch survives JSON round trip
+	data, err := json.Marshal(d)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	var patch Diff
+	if err := json.Unmarshal(data, &patch); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+
+	if err := Apply(a, &patch); err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	d, err = Compare(a, b)
+	if err != nil {
+		t.Fatalf("Compare failed: %v", err)
+	}
+	if !d.Empty() {
+		t.Fatalf("Expected patched graph to be equal, but got diff %+v", d)
+	}
+}
+
+func TestApplyInvalid(t *testing.T) {
+	a, _ := testGraphs()
+	patches := []*Diff{
+		{RemovedNodes: []string{"x"}},
+		{RemovedLinks: []*LinkRef{{Source: "a", Target: "c"}}},
+		{AddedNodes: []graph.Node{graph.NewBasicNode("a")}},
+		{ChangedNodes: []*NodeChange{{ID: "d"}}, RemovedNodes: []string{"d"}},
+		{AddedLinks: []*graph.Link{graph.NewLink("a", "x")}},
+		// valid change followed by invalid one
+		{RemovedNodes: []string{"d"}, AddedLinks: []*graph.Link{graph.NewLink("c", "d")}},
+		{
+			RemovedLinks: []*LinkRef{{Source: "a", Target: "b"}},
+			ChangedNodes: []*NodeChange{{ID: "a", Set: map[string]interface{}{"group": "x"}}},
+		},
+	}
+	for i, patch := range patches {
+		if err := Apply(a, patch); err == nil {
+			t.Fatalf("Patch %d: expected error", i)
+		}
+		if a.NumNodes() != 4 || a.NumLinks() != 3 {
+			t.Fatalf("Patch %d: expected graph to be unmodified", i)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package distance
+
+import "github.com/divan/graphx/graph"
+
+// direction specifies which links BFS follows in directed graphs.
+type direction int
+
+const (
+	outgoing direction = iota
+	incoming
+	both
+)
+
+// bfs holds reusable buffers for breadth-first search over CSR snapshot.
+type bfs struct {
+	dist  []int32
+	queue []int32
+	dir   direction
+}
+
+// newBFS creates new BFS helper for graph with n nodes.
+func newBFS(n int) *bfs {
+	return &bfs{
+		dist:  make([]int32, n),
+		queue: make([]int32, 0, n),
+	}
+}
+
+// run calculates hop distances from src to all nodes, leaving -1 for
+// unreachable ones. It returns eccentricity of src and index of the
+// farthest node. For undirected graphs b.dir is ignored.
+func (b *bfs) run(c *graph.CSR, src int) (ecc, far int) {
+	for i := range b.dist {
+		b.dist[i] = -1
+	}
+
+	b.dist[src] = 0
+	b.queue = append(b.queue[:0], int32(src))
+	far = src
+	for head := 0; head < len(b.queue); head++ {
+		u := b.queue[head]
+		visit := func(neighbors []int32) {
+			for _, v := range neighbors {
+				if b.dist[v] != -1 {
+					continue
+				}
+				b.dist[v] = b.dist[u] + 1
+				b.queue = append(b.queue, v)
+				far = int(v)
+			}
+		}
+		if !c.Directed() || b.dir != incoming {
+			visit(c.Neighbors(int(u)))
+		}
+		if c.Directed() && b.dir != outgoing {
+			visit(c.InNeighbors(int(u)))
+		}
+	}
+	return int(b.dist[far]), far
+}
@@ -0,0 +1,192 @@
+// Package distance implements hop distance based graph metrics: all-pairs
+// distances, eccentricity, diameter, radius, center and periphery.
+//
+// Exact computation requires BFS from every node, which is O(N*(N+M)) and is
+// suitable only for small and medium graphs. For large graphs, sampled mode
+// runs BFS only from a limited number of random nodes and returns lower bound
+// estimates.
+//
+// For disconnected graphs, distances are measured only within components,
+// i.e. unreachable nodes are ignored. For directed graphs, outgoing links
+// are followed, unless Options.IgnoreDirection is set.
+package distance
+
+import (
+	"math/rand"
+
+	"github.com/divan/graphx/graph"
+)
+
+// Options specifies parameters for distance metrics computation.
+// Nil options mean exact computation.
+type Options struct {
+	// Samples sets number of random source nodes for approximate computation.
+	// Zero or value larger than number of nodes means exact computation.
+	Samples int
+	// Rand is a source of randomness for sampling. If nil, deterministic
+	// source is used.
+	Rand *rand.Rand
+	// IgnoreDirection makes links followed in both directions for
+	// directed graphs.
+	IgnoreDirection bool
+}
+
+// exact returns true if options require exact computation for n nodes.
+func (o *Options) exact(n int) bool {
+	return o == nil || o.Samples <= 0 || o.Samples >= n
+}
+
+// direction returns links direction to follow.
+func (o *Options) direction() direction {
+	if o != nil && o.IgnoreDirection {
+		return both
+	}
+	return outgoing
+}
+
+// AllPairs returns hop distances between all pairs of nodes as a matrix
+// indexed by node indices. Unreachable nodes have distance -1.
+func AllPairs(g *graph.Graph) [][]int {
+	c := g.Freeze()
+	n := c.NumNodes()
+	b := newBFS(n)
+	ret := make([][]int, n)
+	for i := 0; i < n; i++ {
+		b.run(c, i)
+		ret[i] = make([]int, n)
+		for j, d := range b.dist {
+			ret[i][j] = int(d)
+		}
+	}
+	return ret
+}
+
+// Eccentricity returns eccentricity of each node, which is the maximum
+// hop distance to any reachable node, keyed by node ID. In sampled mode,
+// values are lower bounds.
+func Eccentricity(g *graph.Graph, opts *Options) map[string]int {
+	c := g.Freeze()
+	ecc := eccentricities(c, opts)
+	ret := make(map[string]int, len(ecc))
+	for i, e := range ecc {
+		ret[c.ID(i)] = e
+	}
+	return ret
+}
+
+// Diameter returns graph diameter, which is the maximum eccentricity.
+// In sampled mode, it's a lower bound, refined with double sweep technique.
+func Diameter(g *graph.Graph, opts *Options) int {
+	c := g.Freeze()
+	ecc := eccentricities(c, opts)
+	var diameter int
+	for _, e := range ecc {
+		if e > diameter {
+			diameter = e
+		}
+	}
+	return diameter
+}
+
+// Radius returns graph radius, which is the minimum eccentricity.
+func Radius(g *graph.Graph, opts *Options) int {
+	c := g.Freeze()
+	ecc := eccentricities(c, opts)
+	if len(ecc) == 0 {
+		return 0
+	}
+	radius := ecc[0]
+	for _, e := range ecc {
+		if e < radius {
+			radius = e
+		}
+	}
+	return radius
+}
+
+// Center returns IDs of the nodes with eccentricity equal to radius.
+func Center(g *graph.Graph, opts *Options) []string {
+	return extremes(g, opts, func(e, best int) bool { return e < best })
+}
+
+// Periphery returns IDs of the nodes with eccentricity equal to diameter.
+func Periphery(g *graph.Graph, opts *Options) []string {
+	return extremes(g, opts, func(e, best int) bool { return e > best })
+}
+
+// extremes returns IDs of the nodes with the best eccentricity, according
+// to the better function.
+func extremes(g *graph.Graph, opts *Options, better func(e, best int) bool) []string {
+	c := g.Freeze()
+	ecc := eccentricities(c, opts)
+
+	if len(ecc) == 0 {
+		return nil
+	}
+
+	best := ecc[0]
+	for _, e := range ecc {
+		if better(e, best) {
+			best = e
+		}
+	}
+
+	var ret []string
+	for i, e := range ecc {
+		if e == best {
+			ret = append(ret, c.ID(i))
+		}
+	}
+	return ret
+}
+
+// eccentricities calculates eccentricity for each node index, either
+// exactly or using sampling.
+func eccentricities(c *graph.CSR, opts *Options) []int {
+	n := c.NumNodes()
+	ecc := make([]int, n)
+	b := newBFS(n)
+	b.dir = opts.direction()
+	if opts.exact(n) {
+		for i := 0; i < n; i++ {
+			ecc[i], _ = b.run(c, i)
+		}
+		return ecc
+	}
+
+	r := opts.Rand
+	if r == nil {
+		r = rand.New(rand.NewSource(1))
+	}
+
+	// every BFS gives exact eccentricity for the source, and distance to the
+	// source is a lower bound for eccentricity of other nodes. When direction
+	// matters, such distances are found with BFS over incoming links.
+	bounds := b
+	if c.Directed() && b.dir == outgoing {
+		bounds = newBFS(n)
+		bounds.dir = incoming
+	}
+	update := func(src int) int {
+		e, far := b.run(c, src)
+		if bounds != b {
+			bounds.run(c, src)
+		}
+		for i, d := range bounds.dist {
+			if int(d) > ecc[i] {
+				ecc[i] = int(d)
+			}
+		}
+		ecc[src] = e
+		return far
+	}
+	for _, src := range r.Perm(n)[:opts.Samples/2] {
+		// double sweep: the farthest node is likely to be peripheral
+		far := update(src)
+		update(far)
+	}
+	if opts.Samples%2 == 1 {
+		update(r.Intn(n))
+	}
+	return ecc
+}
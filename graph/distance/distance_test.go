@@ -0,0 +1,102 @@
+package distance_test
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/divan/graphx/formats"
+	"github.com/divan/graphx/generation/basic"
+	"github.com/divan/graphx/graph"
+	"github.com/divan/graphx/graph/distance"
+)
+
+func TestLine(t *testing.T) {
+	g := basic.NewLineGenerator(5).Generate()
+
+	ecc := distance.Eccentricity(g, nil)
+	expected := map[string]int{"0": 4, "1": 3, "2": 2, "3": 3, "4": 4}
+	if !reflect.DeepEqual(ecc, expected) {
+		t.Fatalf("Expected eccentricity %v, but got %v", expected, ecc)
+	}
+	if d := distance.Diameter(g, nil); d != 4 {
+		t.Fatalf("Expected diameter %d, but got %d", 4, d)
+	}
+	if r := distance.Radius(g, nil); r != 2 {
+		t.Fatalf("Expected radius %d, but got %d", 2, r)
+	}
+	if c := distance.Center(g, nil); !reflect.DeepEqual(c, []string{"2"}) {
+		t.Fatalf("Expected center [2], but got %v", c)
+	}
+	if p := distance.Periphery(g, nil); !reflect.DeepEqual(p, []string{"0", "4"}) {
+		t.Fatalf("Expected periphery [0 4], but got %v", p)
+	}
+
+	dist := distance.AllPairs(g)
+	if dist[0][4] != 4 || dist[3][1] != 2 || dist[2][2] != 0 {
+		t.Fatalf("Unexpected all-pairs distances: %v", dist)
+	}
+}
+
+func TestGrid(t *testing.T) {
+	rows, cols := 5, 7
+	g := basic.NewGrid2DGenerator(rows, cols).Generate()
+	if d := distance.Diameter(g, nil); d != rows+cols-2 {
+		t.Fatalf("Expected diameter %d, but got %d", rows+cols-2, d)
+	}
+	// double sweep finds exact diameter for grids
+	if d := distance.Diameter(g, &distance.Options{Samples: 2}); d != rows+cols-2 {
+		t.Fatalf("Expected approximate diameter %d, but got %d", rows+cols-2, d)
+	}
+}
+
+func TestSampled(t *testing.T) {
+	g, err := formats.FromD3JSON("../../formats/testdata/net1k.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exact := distance.Eccentricity(g, nil)
+	approx := distance.Eccentricity(g, &distance.Options{Samples: 10})
+	for id, e := range approx {
+		if e > exact[id] {
+			t.Fatalf("Expected approximation to be lower bound, but got %d > %d for %s", e, exact[id], id)
+		}
+	}
+
+	d, da := distance.Diameter(g, nil), distance.Diameter(g, &distance.Options{Samples: 10})
+	if da > d || da < d-1 {
+		t.Fatalf("Expected approximate diameter to be close to %d, but got %d", d, da)
+	}
+}
+
+func TestDirected(t *testing.T) {
+	// directed line 0 -> 1 -> ... -> 9
+	g := graph.NewDirectedGraph()
+	for i := 0; i < 10; i++ {
+		g.AddNode(graph.NewBasicNode(strconv.Itoa(i)))
+		if i > 0 {
+			g.AddLink(strconv.Itoa(i-1), strconv.Itoa(i))
+		}
+	}
+
+	exact := distance.Eccentricity(g, nil)
+	if exact["0"] != 9 || exact["9"] != 0 {
+		t.Fatalf("Unexpected eccentricity: %v", exact)
+	}
+	approx := distance.Eccentricity(g, &distance.Options{Samples: 4})
+	for id, e := range approx {
+		if e > exact[id] {
+			t.Fatalf("Expected approximation to be lower bound, but got %d > %d for %s", e, exact[id], id)
+		}
+	}
+
+	opts := &distance.Options{IgnoreDirection: true}
+	if e := distance.Eccentricity(g, opts); e["9"] != 9 || e["4"] != 5 {
+		t.Fatalf("Unexpected eccentricity with direction ignored: %v", e)
+	}
+	opts.Samples = 4
+	if d := distance.Diameter(g, opts); d != 9 {
+		t.Fatalf("Expected diameter %d, but got %d", 9, d)
+	}
+}
@@ -0,0 +1,114 @@
+package flow
+
+import "math"
+
+// eps is a tolerance for treating residual capacity as zero.
+const eps = 1e-12
+
+// network represents flow network with residual capacities. Arcs are
+// stored in pairs, so arc e^1 is reverse for arc e.
+type network struct {
+	adj      [][]int   // arc indices for each node
+	to       []int     // arc target
+	residual []float64 // arc residual capacity
+
+	level []int
+	next  []int // next arc to try for each node in blocking flow search
+}
+
+func newNetwork(n int) *network {
+	return &network{
+		adj:   make([][]int, n),
+		level: make([]int, n),
+		next:  make([]int, n),
+	}
+}
+
+// addArc adds arc from u to v with given capacity, and reverse arc with
+// reverse capacity.
+func (net *network) addArc(u, v int, capacity, reverse float64) {
+	net.adj[u] = append(net.adj[u], len(net.to))
+	net.to = append(net.to, v)
+	net.residual = append(net.residual, capacity)
+	net.adj[v] = append(net.adj[v], len(net.to))
+	net.to = append(net.to, u)
+	net.residual = append(net.residual, reverse)
+}
+
+// maxFlow runs Dinic's algorithm and returns maximum flow value.
+func (net *network) maxFlow(s, t int) float64 {
+	var flow float64
+	for net.bfs(s, t) {
+		for i := range net.next {
+			net.next[i] = 0
+		}
+		for {
+			f := net.dfs(s, t, math.Inf(1))
+			if f <= eps {
+				break
+			}
+			flow += f
+		}
+	}
+	return flow
+}
+
+// bfs builds level graph and returns true if sink is reachable.
+func (net *network) bfs(s, t int) bool {
+	for i := range net.level {
+		net.level[i] = -1
+	}
+	net.level[s] = 0
+	queue := []int{s}
+	for head := 0; head < len(queue); head++ {
+		u := queue[head]
+		for _, e := range net.adj[u] {
+			v := net.to[e]
+			if net.residual[e] > eps && net.level[v] == -1 {
+				net.level[v] = net.level[u] + 1
+				queue = append(queue, v)
+			}
+		}
+	}
+	return net.level[t] != -1
+}
+
+// dfs finds augmenting path in the level graph and pushes flow along it.
+func (net *network) dfs(u, t int, limit float64) float64 {
+	if u == t {
+		return limit
+	}
+	for ; net.next[u] < len(net.adj[u]); net.next[u]++ {
+		e := net.adj[u][net.next[u]]
+		v := net.to[e]
+		if net.residual[e] <= eps || net.level[v] != net.level[u]+1 {
+			continue
+		}
+		f := net.dfs(v, t, math.Min(limit, net.residual[e]))
+		if f > eps {
+			net.residual[e] -= f
+			net.residual[e^1] += f
+			return f
+		}
+	}
+	return 0
+}
+
+// reachable returns nodes reachable from s in the residual network.
+func (net *network) reachable(s int) []bool {
+	ret := make([]bool, len(net.adj))
+	ret[s] = true
+	stack := []int{s}
+	for len(stack) > 0 {
+		u := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, e := range net.adj[u] {
+			v := net.to[e]
+			if net.residual[e] > eps && !ret[v] {
+				ret[v] = true
+				stack = append(stack, v)
+			}
+		}
+	}
+	return ret
+}
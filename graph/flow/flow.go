@@ -0,0 +1,207 @@
+// Package flow implements maximum flow and minimum cut computation using
+// Dinic's algorithm, as well as node and edge connectivity helpers built
+// on top of it.
+//
+// Link weights are used as capacities. Links of undirected graphs can carry
+// flow in both directions.
+package flow
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/divan/graphx/graph"
+)
+
+// ErrSameNode is returned when source and sink are the same node.
+var ErrSameNode = errors.New("source and sink are the same node")
+
+// Result represents maximum flow between two nodes.
+type Result struct {
+	Value float64 // maximum flow value, equal to the minimum cut capacity
+	// Cut holds links of the minimum cut, i.e. links from the source side
+	// to the sink side.
+	Cut []*graph.Link
+	// SourceSide holds IDs of the nodes on the source side of the cut.
+	SourceSide []string
+}
+
+// MaxFlow returns maximum flow from source to sink with capacities taken from
+// link weights, together with the corresponding minimum cut.
+func MaxFlow(g *graph.Graph, source, sink string) (*Result, error) {
+	s, t, err := terminals(g, source, sink)
+	if err != nil {
+		return nil, err
+	}
+
+	net := newNetwork(g.NumNodes())
+	for _, link := range g.Links() {
+		from, to := link.FromIdx(), link.ToIdx()
+		if from == to {
+			continue
+		}
+		reverse := 0.0
+		if !g.Directed() {
+			reverse = link.Weight()
+		}
+		net.addArc(from, to, link.Weight(), reverse)
+	}
+
+	value := net.maxFlow(s, t)
+	reached := net.reachable(s)
+
+	ret := &Result{Value: value}
+	for i, node := range g.Nodes() {
+		if reached[i] {
+			ret.SourceSide = append(ret.SourceSide, node.ID())
+		}
+	}
+	for _, link := range g.Links() {
+		from, to := reached[link.FromIdx()], reached[link.ToIdx()]
+		if (from && !to) || (!g.Directed() && to && !from) {
+			ret.Cut = append(ret.Cut, link)
+		}
+	}
+	return ret, nil
+}
+
+// EdgeConnectivity returns local edge connectivity between two nodes: minimum
+// number of links which removal disconnects sink from source, which is equal
+// to the number of link-disjoint paths between them. Link weights are ignored.
+func EdgeConnectivity(g *graph.Graph, source, sink string) (int, error) {
+	s, t, err := terminals(g, source, sink)
+	if err != nil {
+		return 0, err
+	}
+	return edgeConnectivity(g, s, t), nil
+}
+
+// NodeConnectivity returns local node connectivity between two nodes: number
+// of paths between them, which don't share any nodes except source and sink.
+// If nodes are adjacent, the direct link counts as one such path.
+func NodeConnectivity(g *graph.Graph, source, sink string) (int, error) {
+	s, t, err := terminals(g, source, sink)
+	if err != nil {
+		return 0, err
+	}
+	return nodeConnectivity(g.Freeze(), s, t), nil
+}
+
+// GlobalEdgeConnectivity returns minimum number of links which removal
+// disconnects the graph. It's zero for disconnected graphs and graphs with
+// less than two nodes.
+func GlobalEdgeConnectivity(g *graph.Graph) int {
+	n := g.NumNodes()
+	if n < 2 {
+		return 0
+	}
+
+	// every cut separates node 0 from some other node
+	min := -1
+	for t := 1; t < n; t++ {
+		k := edgeConnectivity(g, 0, t)
+		if g.Directed() {
+			if r := edgeConnectivity(g, t, 0); r < k {
+				k = r
+			}
+		}
+		if min == -1 || k < min {
+			min = k
+		}
+	}
+	return min
+}
+
+// GlobalNodeConnectivity returns minimum number of nodes which removal
+// disconnects the graph. For complete graphs it's the number of nodes
+// minus one. It requires max flow computation for every pair of non-adjacent
+// nodes, so it's suitable only for small graphs.
+func GlobalNodeConnectivity(g *graph.Graph) int {
+	c := g.Freeze()
+	n := c.NumNodes()
+	if n < 2 {
+		return 0
+	}
+
+	adjacent := make([]map[int32]bool, n)
+	for u := 0; u < n; u++ {
+		adjacent[u] = make(map[int32]bool, c.Degree(u))
+		for _, v := range c.Neighbors(u) {
+			adjacent[u][v] = true
+		}
+	}
+
+	min := n - 1
+	for s := 0; s < n; s++ {
+		for t := 0; t < n; t++ {
+			if s == t || adjacent[s][int32(t)] {
+				continue
+			}
+			if !c.Directed() && t < s {
+				continue
+			}
+			if k := nodeConnectivity(c, s, t); k < min {
+				min = k
+			}
+		}
+	}
+	return min
+}
+
+// edgeConnectivity calculates max flow with unit capacities.
+func edgeConnectivity(g *graph.Graph, s, t int) int {
+	net := newNetwork(g.NumNodes())
+	for _, link := range g.Links() {
+		from, to := link.FromIdx(), link.ToIdx()
+		if from == to {
+			continue
+		}
+		reverse := 0.0
+		if !g.Directed() {
+			reverse = 1
+		}
+		net.addArc(from, to, 1, reverse)
+	}
+	return int(net.maxFlow(s, t) + 0.5)
+}
+
+// nodeConnectivity calculates max flow in the network where each node
+// except source and sink is split into in and out parts, connected with
+// unit capacity arc. Node i is represented by in part 2*i and out part 2*i+1.
+func nodeConnectivity(c *graph.CSR, s, t int) int {
+	n := c.NumNodes()
+	net := newNetwork(2 * n)
+	for u := 0; u < n; u++ {
+		capacity := 1.0
+		if u == s || u == t {
+			capacity = float64(n)
+		}
+		net.addArc(2*u, 2*u+1, capacity, 0)
+	}
+	for u := 0; u < n; u++ {
+		for _, v := range c.Neighbors(u) {
+			if int(v) == u {
+				continue
+			}
+			// undirected links are listed for both nodes
+			net.addArc(2*u+1, 2*int(v), 1, 0)
+		}
+	}
+	return int(net.maxFlow(2*s, 2*t+1) + 0.5)
+}
+
+// terminals returns indices of source and sink nodes.
+func terminals(g *graph.Graph, source, sink string) (int, int, error) {
+	s, err := g.NodeByID(source)
+	if err != nil {
+		return 0, 0, err
+	}
+	t, err := g.NodeByID(sink)
+	if err != nil {
+		return 0, 0, err
+	}
+	if s == t {
+		return 0, 0, fmt.Errorf("%w: %s", ErrSameNode, source)
+	}
+	return s, t, nil
+}
@@ -0,0 +1,137 @@
+package flow
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/divan/graphx/generation/basic"
+	"github.com/divan/graphx/generation/net"
+	"github.com/divan/graphx/graph/internal/graphtest"
+)
+
+func TestMaxFlow(t *testing.T) {
+	// classic example: s -> a, s -> b, a -> b, a -> t, b -> t
+	g := graphtest.New(true, []string{"s", "a", "b", "t"})
+	g.AddWeightedLink("s", "a", 3)
+	g.AddWeightedLink("s", "b", 2)
+	g.AddWeightedLink("a", "b", 1)
+	g.AddWeightedLink("a", "t", 2)
+	g.AddWeightedLink("b", "t", 3)
+
+	res, err := MaxFlow(g, "s", "t")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(res.Value-5) > 1e-9 {
+		t.Fatalf("Expected max flow %v, but got %v", 5, res.Value)
+	}
+	var cut float64
+	for _, l := range res.Cut {
+		cut += l.Weight()
+	}
+	if math.Abs(cut-res.Value) > 1e-9 {
+		t.Fatalf("Expected min cut capacity %v, but got %v", res.Value, cut)
+	}
+
+	// flow against links direction is not possible
+	res, err = MaxFlow(g, "t", "s")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Value != 0 || len(res.Cut) != 0 {
+		t.Fatalf("Expected zero flow, but got %v (cut %v)", res.Value, res.Cut)
+	}
+}
+
+func TestMaxFlowErrors(t *testing.T) {
+	g := graphtest.New(false, []string{"a", "b"})
+	if _, err := MaxFlow(g, "a", "a"); !errors.Is(err, ErrSameNode) {
+		t.Fatalf("Expected %v, but got %v", ErrSameNode, err)
+	}
+	if _, err := MaxFlow(g, "a", "x"); err == nil {
+		t.Fatalf("Expected error for unknown node")
+	}
+}
+
+func TestSplitBrain(t *testing.T) {
+	g := net.NewSplitBrainGenerator(40, 3, "10.0.0.0").Generate()
+	// generator links hemispheres with a single bridge between nodes 10 and 30,
+	// while hemispheres themselves are random and may be not connected
+	left, right := "10.0.0.11", "10.0.0.31"
+
+	res, err := MaxFlow(g, left, right)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Value != 1 || len(res.Cut) != 1 {
+		t.Fatalf("Expected single link cut, but got %v (cut %v)", res.Value, res.Cut)
+	}
+	l := res.Cut[0]
+	if l.From() != "10.0.0.11" || l.To() != "10.0.0.31" {
+		t.Fatalf("Expected bridge to be cut, but got %s - %s", l.From(), l.To())
+	}
+	for _, id := range res.SourceSide {
+		idx, _ := g.NodeByID(id)
+		if idx >= 20 {
+			t.Fatalf("Expected source side to contain only left hemisphere, but got %s", id)
+		}
+	}
+
+	// generated hemispheres may be not connected, so global connectivity is
+	// checked on two 4-node cliques linked by a single bridge a0 - b0
+	g = graphtest.New(false, []string{"a0", "a1", "a2", "a3", "b0", "b1", "b2", "b3"})
+	for _, p := range []string{"a", "b"} {
+		for i := 0; i < 4; i++ {
+			for j := i + 1; j < 4; j++ {
+				g.AddLink(p+string(rune('0'+i)), p+string(rune('0'+j)))
+			}
+		}
+	}
+	g.AddLink("a0", "b0")
+	if k := GlobalEdgeConnectivity(g); k != 1 {
+		t.Fatalf("Expected global edge connectivity %d, but got %d", 1, k)
+	}
+	if k := GlobalNodeConnectivity(g); k != 1 {
+		t.Fatalf("Expected global node connectivity %d, but got %d", 1, k)
+	}
+}
+
+func TestConnectivity(t *testing.T) {
+	// 4x4 grid: corners have degree 2, inner nodes have degree 4
+	g := basic.NewGrid2DGenerator(4, 4).Generate()
+	nodes := g.Nodes()
+	corner, inner1, inner2 := nodes[0].ID(), nodes[5].ID(), nodes[10].ID()
+
+	if k, _ := EdgeConnectivity(g, inner1, inner2); k != 4 {
+		t.Fatalf("Expected edge connectivity %d, but got %d", 4, k)
+	}
+	if k, _ := NodeConnectivity(g, corner, inner2); k != 2 {
+		t.Fatalf("Expected node connectivity %d, but got %d", 2, k)
+	}
+	if k := GlobalEdgeConnectivity(g); k != 2 {
+		t.Fatalf("Expected global edge connectivity %d, but got %d", 2, k)
+	}
+	if k := GlobalNodeConnectivity(g); k != 2 {
+		t.Fatalf("Expected global node connectivity %d, but got %d", 2, k)
+	}
+
+	// two triangles sharing node c: edge connectivity 2, node connectivity 1
+	bowtie := graphtest.New(false, []string{"a", "b", "c", "d", "e"})
+	for _, l := range [][2]string{{"a", "b"}, {"b", "c"}, {"c", "a"}, {"c", "d"}, {"d", "e"}, {"e", "c"}} {
+		bowtie.AddLink(l[0], l[1])
+	}
+	if k, _ := EdgeConnectivity(bowtie, "a", "e"); k != 2 {
+		t.Fatalf("Expected edge connectivity %d, but got %d", 2, k)
+	}
+	if k, _ := NodeConnectivity(bowtie, "a", "e"); k != 1 {
+		t.Fatalf("Expected node connectivity %d, but got %d", 1, k)
+	}
+	if k := GlobalNodeConnectivity(bowtie); k != 1 {
+		t.Fatalf("Expected global node connectivity %d, but got %d", 1, k)
+	}
+	// adjacent nodes: direct link and path through c
+	if k, _ := NodeConnectivity(bowtie, "a", "b"); k != 2 {
+		t.Fatalf("Expected node connectivity %d, but got %d", 2, k)
+	}
+}
@@ -1,61 +1,141 @@
 package graph
 
-// Graph represents graph data.
-type Graph struct {
-	nodes []Node
+// Graph represents graph data with nodes stored as Node interface values.
+// It's the most common graph type, used by layouts, generators and exporters.
+type Graph = TypedGraph[Node]
+
+// TypedGraph represents graph data with nodes of type N. Graph can be either
+// undirected (default) or directed, in which case links direction is respected
+// by lookups and neighbors queries.
+type TypedGraph[N Node] struct {
+	nodes []N
 	links []*Link
 
-	nodeLinks   map[string]int
-	nodeIdxByID map[string]int
+	directed bool
+
+	nodeLinks    map[string]int
+	nodeOutLinks map[string]int
+	nodeIdxByID  map[string]int
+
+	adj   [][]int // neighbors indices for each node (outgoing for directed)
+	inAdj [][]int // incoming neighbors indices, for directed graph only
+	edges map[edge]edgeRef
 }
 
 // NewGraph creates empty graph data.
 func NewGraph() *Graph {
-	return &Graph{
-		nodeLinks:   make(map[string]int),
-		nodeIdxByID: make(map[string]int),
-	}
+	return NewTypedGraphMN[Node](0, 0)
 }
 
 // NewGraphMN creates empty graph for M nodes and N links.
 // It preallocates memory for the specified sizes.
 func NewGraphMN(m, n int) *Graph {
-	return &Graph{
-		nodes:       make([]Node, 0, m),
-		links:       make([]*Link, 0, n),
-		nodeLinks:   make(map[string]int),
-		nodeIdxByID: make(map[string]int),
+	return NewTypedGraphMN[Node](m, n)
+}
+
+// NewDirectedGraph creates empty directed graph data.
+func NewDirectedGraph() *Graph {
+	return NewDirectedTypedGraphMN[Node](0, 0)
+}
+
+// NewDirectedGraphMN creates empty directed graph for M nodes and N links.
+// It preallocates memory for the specified sizes.
+func NewDirectedGraphMN(m, n int) *Graph {
+	return NewDirectedTypedGraphMN[Node](m, n)
+}
+
+// NewTypedGraph creates empty graph data with nodes of type N.
+func NewTypedGraph[N Node]() *TypedGraph[N] {
+	return NewTypedGraphMN[N](0, 0)
+}
+
+// NewTypedGraphMN creates empty graph with nodes of type N for M nodes and N links.
+// It preallocates memory for the specified sizes.
+func NewTypedGraphMN[N Node](m, n int) *TypedGraph[N] {
+	return &TypedGraph[N]{
+		nodes:        make([]N, 0, m),
+		links:        make([]*Link, 0, n),
+		nodeLinks:    make(map[string]int),
+		nodeOutLinks: make(map[string]int),
+		nodeIdxByID:  make(map[string]int),
+		adj:          make([][]int, 0, m),
+		edges:        make(map[edge]edgeRef, n),
 	}
 }
 
+// NewDirectedTypedGraph creates empty directed graph data with nodes of type N.
+func NewDirectedTypedGraph[N Node]() *TypedGraph[N] {
+	return NewDirectedTypedGraphMN[N](0, 0)
+}
+
+// NewDirectedTypedGraphMN creates empty directed graph with nodes of type N
+// for M nodes and N links. It preallocates memory for the specified sizes.
+func NewDirectedTypedGraphMN[N Node](m, n int) *TypedGraph[N] {
+	g := NewTypedGraphMN[N](m, n)
+	g.directed = true
+	g.inAdj = make([][]int, 0, m)
+	return g
+}
+
+// Directed returns true if graph is directed.
+func (g *TypedGraph[N]) Directed() bool {
+	return g.directed
+}
+
 // Nodes returns graph nodes
-func (g *Graph) Nodes() []Node {
+func (g *TypedGraph[N]) Nodes() []N {
 	return g.nodes
 }
 
 // Links returns graph links.
-func (g *Graph) Links() []*Link {
+func (g *TypedGraph[N]) Links() []*Link {
 	return g.links
 }
 
 // NumNodes returns total number of graph nodes.
-func (g *Graph) NumNodes() int {
+func (g *TypedGraph[N]) NumNodes() int {
 	return len(g.nodes)
 }
 
 // NumLinks returns total number of graph links.
-func (g *Graph) NumLinks() int {
+func (g *TypedGraph[N]) NumLinks() int {
 	return len(g.links)
 }
 
 // UpdateCache runs various optimization-related
 // calculations, caching etc.
-func (g *Graph) UpdateCache() {
+func (g *TypedGraph[N]) UpdateCache() {
 	g.ResetCache()
-	for _, link := range g.links {
-		g.cacheLink(link.from, link.to)
-	}
 	for i, node := range g.nodes {
 		g.cacheNode(node, i)
 	}
+	g.adj = make([][]int, len(g.nodes))
+	if g.directed {
+		g.inAdj = make([][]int, len(g.nodes))
+	}
+	for i, link := range g.links {
+		link.fromIdx = g.nodeIdxByID[link.from]
+		link.toIdx = g.nodeIdxByID[link.to]
+		g.cacheLink(link.from, link.to)
+		g.indexLink(link, i)
+	}
+}
+
+// Untyped returns a copy of the graph with nodes stored as Node interface
+// values, so it can be used with layouts, exporters and algorithms working
+// with Graph. Nodes are shared between graphs, links are copied.
+func (g *TypedGraph[N]) Untyped() *Graph {
+	ret := NewGraphMN(len(g.nodes), len(g.links))
+	ret.directed = g.directed
+	if g.directed {
+		ret.inAdj = make([][]int, 0, len(g.nodes))
+	}
+	for _, node := range g.nodes {
+		ret.AddNode(node)
+	}
+	for _, link := range g.links {
+		l := *link
+		ret.AddLinks(&l)
+	}
+	return ret
 }
@@ -0,0 +1,291 @@
+package graph
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestRemoveNode(t *testing.T) {
+	g := testGraph(5)
+	// 0-1, 1-2, 2-3, 3-4, 4-0
+	err := g.RemoveNode("2")
+	if err != nil {
+		t.Fatalf("Remove node failed: %v", err)
+	}
+
+	if g.NumNodes() != 4 {
+		t.Fatalf("Expected %d nodes, but got %d", 4, g.NumNodes())
+	}
+	if g.NumLinks() != 3 {
+		t.Fatalf("Expected %d links, but got %d", 3, g.NumLinks())
+	}
+	if g.NodeLinks("1") != 1 || g.NodeLinks("3") != 1 || g.NodeLinks("2") != 0 {
+		t.Fatalf("Unexpected link counts after removal")
+	}
+	if _, err := g.NodeByID("2"); err == nil {
+		t.Fatalf("Expected removed node to be not found")
+	}
+	checkConsistency(t, g)
+}
+
+func TestRemoveNodes(t *testing.T) {
+	g := testGraph(5)
+	err := g.RemoveNodes("0", "3")
+	if err != nil {
+		t.Fatalf("Remove nodes failed: %v", err)
+	}
+	if g.NumNodes() != 3 {
+		t.Fatalf("Expected %d nodes, but got %d", 3, g.NumNodes())
+	}
+	if g.NumLinks() != 1 {
+		t.Fatalf("Expected %d links, but got %d", 1, g.NumLinks())
+	}
+	checkConsistency(t, g)
+
+	err = g.RemoveNodes("1", "nonexistent")
+	if err == nil {
+		t.Fatalf("Expected error for nonexistent node")
+	}
+	if g.NumNodes() != 3 {
+		t.Fatalf("Expected graph to be unmodified on error")
+	}
+}
+
+func TestRemoveLink(t *testing.T) {
+	g := testGraph(5)
+	err := g.RemoveLink("2", "1") // reversed order should work too
+	if err != nil {
+		t.Fatalf("Remove link failed: %v", err)
+	}
+	if g.NumLinks() != 4 {
+		t.Fatalf("Expected %d links, but got %d", 4, g.NumLinks())
+	}
+	if g.LinkExists("1", "2") {
+		t.Fatalf("Expected link to be removed")
+	}
+	if g.NodeLinks("1") != 1 || g.NodeLinks("2") != 1 {
+		t.Fatalf("Unexpected link counts after removal")
+	}
+	if err := g.RemoveLink("1", "2"); err == nil {
+		t.Fatalf("Expected error for removing nonexistent link")
+	}
+	checkConsistency(t, g)
+}
+
+// testGraph creates circle graph with n nodes.
+func testGraph(n int) *Graph {
+	g := NewGraph()
+	for i := 0; i < n; i++ {
+		g.AddNode(NewBasicNode(fmt.Sprintf("%d", i)))
+	}
+	for i := 0; i < n; i++ {
+		g.AddLink(fmt.Sprintf("%d", i), fmt.Sprintf("%d", (i+1)%n))
+	}
+	return g
+}
+
+// checkConsistency verifies that indices and caches match graph data.
+func checkConsistency(t *testing.T, g *Graph) {
+	t.Helper()
+	for i, node := range g.Nodes() {
+		idx, err := g.NodeByID(node.ID())
+		if err != nil || idx != i {
+			t.Fatalf("Node %s: expected index %d, got %d (%v)", node.ID(), i, idx, err)
+		}
+	}
+	counts := make(map[string]int)
+	for _, link := range g.Links() {
+		if g.Nodes()[link.FromIdx()].ID() != link.From() {
+			t.Fatalf("Link %s->%s has wrong source index %d", link.From(), link.To(), link.FromIdx())
+		}
+		if g.Nodes()[link.ToIdx()].ID() != link.To() {
+			t.Fatalf("Link %s->%s has wrong target index %d", link.From(), link.To(), link.ToIdx())
+		}
+		counts[link.From()]++
+		counts[link.To()]++
+	}
+	for _, node := range g.Nodes() {
+		if counts[node.ID()] != g.NodeLinks(node.ID()) {
+			t.Fatalf("Node %s: expected %d links, got %d", node.ID(), counts[node.ID()], g.NodeLinks(node.ID()))
+		}
+	}
+}
+
+func TestNeighbors(t *testing.T) {
+	g := testGraph(5)
+	got, err := g.Neighbors("0")
+	if err != nil {
+		t.Fatalf("Neighbors failed: %v", err)
+	}
+	if len(got) != 2 || got[0] != "1" || got[1] != "4" {
+		t.Fatalf("Expected neighbors [1 4], but got %v", got)
+	}
+
+	g.RemoveLink("0", "1")
+	idx := g.NeighborIndices(0)
+	if len(idx) != 1 || idx[0] != 4 {
+		t.Fatalf("Expected neighbor indices [4], but got %v", idx)
+	}
+
+	g.RemoveNode("2")
+	idx = g.NeighborIndices(2) // former node "3"
+	if len(idx) != 1 || idx[0] != 3 {
+		t.Fatalf("Expected neighbor indices [3], but got %v", idx)
+	}
+}
+
+func TestLinkIndex(t *testing.T) {
+	g := testGraph(5)
+	g.AddLink("2", "1") // duplicate
+	for i, link := range g.Links()[:5] {
+		idx, err := g.LinkIndex(link.To(), link.From())
+		if err != nil || idx != i {
+			t.Fatalf("Expected link index %d, but got %d (%v)", i, idx, err)
+		}
+	}
+
+	g.RemoveLink("0", "1")
+	idx, err := g.LinkByIndices(2, 1)
+	if err != nil || idx != 0 {
+		t.Fatalf("Expected link index %d, but got %d (%v)", 0, idx, err)
+	}
+	g.RemoveLink("1", "2")
+	idx, err = g.LinkByIndices(1, 2)
+	if err != nil || idx != 3 {
+		t.Fatalf("Expected duplicate link index %d, but got %d (%v)", 3, idx, err)
+	}
+	g.RemoveLink("1", "2")
+	if g.LinkExists("1", "2") {
+		t.Fatalf("Expected link to be removed")
+	}
+	checkConsistency(t, g)
+}
+
+func TestRewireLink(t *testing.T) {
+	g := testGraph(5)
+	err := g.RewireLink("1", "2", "4")
+	if err != nil {
+		t.Fatalf("Rewire link failed: %v", err)
+	}
+	if g.LinkExists("1", "2") || !g.LinkExists("4", "1") {
+		t.Fatalf("Expected link to be rewired")
+	}
+	if g.NodeLinks("2") != 1 || g.NodeLinks("4") != 3 {
+		t.Fatalf("Unexpected link counts after rewire")
+	}
+	checkConsistency(t, g)
+}
+
+func TestDirected(t *testing.T) {
+	g := NewDirectedGraph()
+	for _, id := range []string{"a", "b", "c"} {
+		g.AddNode(NewBasicNode(id))
+	}
+	g.AddLink("a", "b")
+	g.AddLink("b", "a")
+	g.AddLink("a", "c")
+
+	if !g.LinkExists("a", "c") || g.LinkExists("c", "a") {
+		t.Fatalf("Expected link a->c to exist only in one direction")
+	}
+	idx, err := g.LinkIndex("b", "a")
+	if err != nil || idx != 1 {
+		t.Fatalf("Expected link index %d, but got %d (%v)", 1, idx, err)
+	}
+	if g.NodeOutLinks("a") != 2 || g.NodeInLinks("a") != 1 || g.NodeLinks("a") != 3 {
+		t.Fatalf("Unexpected degree for node 'a': in %d, out %d", g.NodeInLinks("a"), g.NodeOutLinks("a"))
+	}
+	if g.NodeOutLinks("c") != 0 || g.NodeInLinks("c") != 1 {
+		t.Fatalf("Unexpected degree for node 'c': in %d, out %d", g.NodeInLinks("c"), g.NodeOutLinks("c"))
+	}
+
+	out, _ := g.Neighbors("a")
+	in, _ := g.InNeighbors("a")
+	if len(out) != 2 || len(in) != 1 || in[0] != "b" {
+		t.Fatalf("Unexpected neighbors for node 'a': out %v, in %v", out, in)
+	}
+
+	g.RemoveLink("b", "a")
+	if g.LinkExists("b", "a") || !g.LinkExists("a", "b") {
+		t.Fatalf("Expected only link b->a to be removed")
+	}
+	g.RemoveNode("b")
+	if in, _ := g.InNeighbors("c"); len(in) != 1 || in[0] != "a" {
+		t.Fatalf("Unexpected in neighbors for node 'c': %v", in)
+	}
+	checkConsistency(t, g)
+}
+
+func TestTypedGraph(t *testing.T) {
+	g := NewTypedGraph[*BasicNode]()
+	g.AddNodes(NewBasicNode("a"), NewBasicNode("b"))
+	g.AddWeightedLink("a", "b", 2)
+
+	node, err := g.Node("b")
+	if err != nil {
+		t.Fatalf("Node lookup failed: %v", err)
+	}
+	node.Group_ = 3 // no type assertion needed
+
+	u := g.Untyped()
+	if u.NumNodes() != 2 || u.NumLinks() != 1 || !u.LinkExists("b", "a") {
+		t.Fatalf("Expected untyped graph to have the same nodes and links")
+	}
+	n, _ := u.Node("b")
+	if n.(GroupedNode).Group() != 3 {
+		t.Fatalf("Expected nodes to be shared between graphs")
+	}
+	checkConsistency(t, u)
+}
+
+func TestFreeze(t *testing.T) {
+	g := NewDirectedGraph()
+	for _, id := range []string{"a", "b", "c"} {
+		g.AddNode(NewBasicNode(id))
+	}
+	g.AddWeightedLink("a", "b", 2)
+	g.AddLink("a", "c")
+	g.AddWeightedLink("c", "b", 3)
+
+	c := g.Freeze()
+	if c.NumNodes() != 3 || c.NumLinks() != 3 || !c.Directed() {
+		t.Fatalf("Unexpected snapshot size")
+	}
+	if n := c.Neighbors(0); len(n) != 2 || n[0] != 1 || n[1] != 2 {
+		t.Fatalf("Unexpected neighbors for node 'a': %v", n)
+	}
+	if w := c.Weights(0); w[0] != 2 || w[1] != 1 {
+		t.Fatalf("Unexpected weights for node 'a': %v", w)
+	}
+	if n := c.InNeighbors(1); len(n) != 2 || n[0] != 0 || n[1] != 2 {
+		t.Fatalf("Unexpected in neighbors for node 'b': %v", n)
+	}
+	if l := c.InLinkIndices(1); l[0] != 0 || l[1] != 2 {
+		t.Fatalf("Unexpected in link indices for node 'b': %v", l)
+	}
+	if w := c.InWeights(1); w[1] != 3 {
+		t.Fatalf("Unexpected in weights for node 'b': %v", w)
+	}
+	if c.Degree(1) != 0 || c.InDegree(1) != 2 {
+		t.Fatalf("Unexpected degrees for node 'b'")
+	}
+
+	u := testGraph(4).Freeze()
+	for i := 0; i < u.NumNodes(); i++ {
+		if u.Degree(i) != 2 || u.InDegree(i) != 2 {
+			t.Fatalf("Expected all circle nodes to have degree 2")
+		}
+	}
+}
+
+func TestSetGroups(t *testing.T) {
+	g := testGraph(3)
+	if n := SetGroups(g, map[string]int{"0": 5, "2": 7, "x": 1}); n != 2 {
+		t.Fatalf("Expected %d nodes to be updated, but got %d", 2, n)
+	}
+	for i, expected := range []int{5, 0, 7} {
+		if group := g.Nodes()[i].(GroupedNode).Group(); group != expected {
+			t.Fatalf("Expected node %d to be in group %d, but got %d", i, expected, group)
+		}
+	}
+}
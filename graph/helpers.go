@@ -3,65 +3,78 @@ package graph
 import "fmt"
 
 // NodeHasLinks implements fast check if given node has any links.
-func (g *Graph) NodeHasLinks(id string) bool {
+func (g *TypedGraph[N]) NodeHasLinks(id string) bool {
 	return g.nodeLinks[id] > 0
 }
 
 // NodeLinks returns number of links for node.
-func (g *Graph) NodeLinks(id string) int {
+func (g *TypedGraph[N]) NodeLinks(id string) int {
 	return g.nodeLinks[id]
 }
 
+// NodeInLinks returns number of incoming links for node. For undirected
+// graphs it's the same as NodeLinks.
+func (g *TypedGraph[N]) NodeInLinks(id string) int {
+	if !g.directed {
+		return g.nodeLinks[id]
+	}
+	return g.nodeLinks[id] - g.nodeOutLinks[id]
+}
+
+// NodeOutLinks returns number of outgoing links for node. For undirected
+// graphs it's the same as NodeLinks.
+func (g *TypedGraph[N]) NodeOutLinks(id string) int {
+	if !g.directed {
+		return g.nodeLinks[id]
+	}
+	return g.nodeOutLinks[id]
+}
+
 // NodeIDHasLinks implements fast check if given node by ID has any links.
-func (g *Graph) NodeIDHasLinks(id string) bool {
+func (g *TypedGraph[N]) NodeIDHasLinks(id string) bool {
 	return g.nodeLinks[id] > 0
 }
 
 // LinkExists returns true if there is a link between source and target.
-func (g *Graph) LinkExists(from, to string) bool {
-	for _, link := range g.links {
-		if link.from == from && link.to == to ||
-			link.to == from && link.from == to {
-			return true
-		}
-	}
-	return false
+// For directed graphs only links from source to target are considered.
+func (g *TypedGraph[N]) LinkExists(from, to string) bool {
+	_, err := g.LinkIndex(from, to)
+	return err == nil
 }
 
 // LinkIndex returns link index by its source and target.
-func (g *Graph) LinkIndex(from, to string) (int, error) {
-	for i, link := range g.links {
-		if link.from == from && link.to == to ||
-			link.to == from && link.from == to {
-			return i, nil
-		}
+func (g *TypedGraph[N]) LinkIndex(from, to string) (int, error) {
+	fromIdx, ok := g.nodeIdxByID[from]
+	if !ok {
+		return 0, fmt.Errorf("link %s->%s not found", from, to)
+	}
+	toIdx, ok := g.nodeIdxByID[to]
+	if !ok {
+		return 0, fmt.Errorf("link %s->%s not found", from, to)
 	}
 
-	return 0, fmt.Errorf("link %s->%s not found", from, to)
+	idx, err := g.linkIdx(fromIdx, toIdx)
+	if err != nil {
+		return 0, fmt.Errorf("link %s->%s not found", from, to)
+	}
+	return idx, nil
 }
 
 // LinkByIndices returns link index by its source and target indices.
-func (g *Graph) LinkByIndices(from, to int) (int, error) {
-	for i, link := range g.links {
-		if link.fromIdx == from && link.toIdx == to ||
-			link.toIdx == from && link.fromIdx == to {
-			return i, nil
-		}
-	}
-
-	return 0, fmt.Errorf("link %d->%d not found", from, to)
+func (g *TypedGraph[N]) LinkByIndices(from, to int) (int, error) {
+	return g.linkIdx(from, to)
 }
 
 // NodeByID returns node index by its ID.
 // TODO(divan): rename to NodeIdxByID
-func (g *Graph) NodeByID(id string) (int, error) {
+func (g *TypedGraph[N]) NodeByID(id string) (int, error) {
 	idx, ok := g.nodeIdxByID[id]
 	if !ok {
 		// not in cache, attempt to find and cache
 		for i := range g.nodes {
 			if g.nodes[i].ID() == id {
-				g.cacheNode(g.nodes[i], idx)
-				return idx, nil
+				g.cacheNode(g.nodes[i], i)
+				return i, nil
 			}
 		}
 		return 0, fmt.Errorf("node %s not found", id)
@@ -70,7 +83,7 @@ func (g *Graph) NodeByID(id string) (int, error) {
 }
 
 // NodeIDByIdx returns node ID by its index.
-func (g *Graph) NodeIDByIdx(idx int) (string, error) {
+func (g *TypedGraph[N]) NodeIDByIdx(idx int) (string, error) {
 	if idx < 0 || idx > g.NumNodes()-1 {
 		return "", fmt.Errorf("node for index %d not found", idx)
 	}
@@ -79,10 +92,11 @@ func (g *Graph) NodeIDByIdx(idx int) (string, error) {
 }
 
 // Node returns Node by its string ID. It uses cache for faster lookup.
-func (g *Graph) Node(id string) (Node, error) {
+func (g *TypedGraph[N]) Node(id string) (N, error) {
 	idx, err := g.NodeByID(id)
 	if err != nil {
-		return nil, err
+		var zero N
+		return zero, err
 	}
 	return g.nodes[idx], nil
 }
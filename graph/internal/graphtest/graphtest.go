@@ -0,0 +1,23 @@
+// Package graphtest provides helpers for building small graphs in tests
+// of the graph algorithms packages.
+package graphtest
+
+import "github.com/divan/graphx/graph"
+
+// New creates graph with given node IDs and links, where each link is
+// a pair of source and target IDs. It panics on links to unknown nodes.
+func New(directed bool, ids []string, links ...[2]string) *graph.Graph {
+	g := graph.NewGraph()
+	if directed {
+		g = graph.NewDirectedGraph()
+	}
+	for _, id := range ids {
+		g.AddNode(graph.NewBasicNode(id))
+	}
+	for _, l := range links {
+		if err := g.AddLink(l[0], l[1]); err != nil {
+			panic(err)
+		}
+	}
+	return g
+}
@@ -1,5 +1,10 @@
 package graph
 
+import "encoding/json"
+
+// DefaultLinkWeight is the weight of links created without explicit weight.
+const DefaultLinkWeight = 1.0
+
 // Link represents single link between two nodes.
 type Link struct {
 	from string
@@ -7,37 +12,86 @@ type Link struct {
 
 	fromIdx int
 	toIdx   int
+
+	weight float64
+	attrs  Attrs
 }
 
 // NewLink constructs new Link object.
 // Note, this function doesn't know actual nodes, so it doesn't
 // check for indices validity.
 func NewLink(from, to string) *Link {
+	return NewWeightedLink(from, to, DefaultLinkWeight)
+}
+
+// NewWeightedLink constructs new Link object with the given weight.
+func NewWeightedLink(from, to string, weight float64) *Link {
 	return &Link{
-		from: from,
-		to:   to,
+		from:   from,
+		to:     to,
+		weight: weight,
 	}
 }
 
 // AddLink adds new link to the graph and validates input
 // indices.
-func (g *Graph) AddLink(from, to string) error {
+func (g *TypedGraph[N]) AddLink(from, to string) error {
 	// TODO: add node if ID is unexistent
+	return g.AddLinks(NewLink(from, to))
+}
 
-	link := NewLink(from, to)
+// AddWeightedLink adds new link with the given weight to the graph
+// and validates input indices.
+func (g *TypedGraph[N]) AddWeightedLink(from, to string, weight float64) error {
+	return g.AddLinks(NewWeightedLink(from, to, weight))
+}
 
-	var err error
-	link.fromIdx, err = g.NodeByID(from)
-	if err != nil {
-		return err
+// AddLinks adds prepared links to the graph and validates their
+// indices. It's useful for adding links with attributes.
+// Links are added in order, and it stops on the first invalid link.
+func (g *TypedGraph[N]) AddLinks(links ...*Link) error {
+	for _, link := range links {
+		var err error
+		link.fromIdx, err = g.NodeByID(link.from)
+		if err != nil {
+			return err
+		}
+		link.toIdx, err = g.NodeByID(link.to)
+		if err != nil {
+			return err
+		}
+
+		g.links = append(g.links, link)
+		g.cacheLink(link.from, link.to)
+		g.indexLink(link, len(g.links)-1)
 	}
-	link.toIdx, err = g.NodeByID(to)
+	return nil
+}
+
+// RemoveLink removes link between source and target from the graph.
+// Indices of the remaining links are updated accordingly.
+func (g *TypedGraph[N]) RemoveLink(from, to string) error {
+	idx, err := g.LinkIndex(from, to)
 	if err != nil {
 		return err
 	}
 
-	g.links = append(g.links, link)
-	g.cacheLink(from, to)
+	link := g.links[idx]
+	g.unindexLink(link, idx)
+	g.uncacheLink(link.from, link.to)
+
+	copy(g.links[idx:], g.links[idx+1:])
+	g.links[len(g.links)-1] = nil
+	g.links = g.links[:len(g.links)-1]
+
+	// links after idx are shifted now
+	for i := idx; i < len(g.links); i++ {
+		key := g.edgeKey(g.links[i].fromIdx, g.links[i].toIdx)
+		if ref := g.edges[key]; ref.idx == i+1 {
+			ref.idx = i
+			g.edges[key] = ref
+		}
+	}
 	return nil
 }
 
@@ -53,8 +107,85 @@ func (l *Link) FromIdx() int { return l.fromIdx }
 // ToIdx returns link's target index.
 func (l *Link) ToIdx() int { return l.toIdx }
 
-// Rewire allows explicitly change edge.
+// Weight returns link's weight.
+func (l *Link) Weight() float64 { return l.weight }
+
+// SetWeight sets link's weight.
+func (l *Link) SetWeight(weight float64) { l.weight = weight }
+
+// Attrs returns link's attributes. Returned map may be nil.
+func (l *Link) Attrs() Attrs { return l.attrs }
+
+// Attr returns link's attribute value by its key.
+func (l *Link) Attr(key string) (interface{}, bool) {
+	v, ok := l.attrs[key]
+	return v, ok
+}
+
+// SetAttr sets link's attribute value.
+func (l *Link) SetAttr(key string, value interface{}) {
+	if l.attrs == nil {
+		l.attrs = make(Attrs)
+	}
+	l.attrs[key] = value
+}
+
+// Copy returns copy of the link with its own attributes map. Indices
+// of the copy are updated when it's added to a graph.
+func (l *Link) Copy() *Link {
+	ret := *l
+	ret.attrs = l.attrs.Copy()
+	return &ret
+}
+
+// Rewire allows explicitly change edge. It doesn't update graph
+// indices and caches, so either use Graph.RewireLink, or call
+// Graph.UpdateCache afterwards.
 func (l *Link) Rewire(from, to string) {
 	l.from = from
 	l.to = to
 }
+
+// linkJSONFields lists link fields, which are not stored as attributes.
+var linkJSONFields = map[string]bool{"source": true, "target": true, "weight": true}
+
+// linkJSON represents link in JSON, compatible with D3 format.
+type linkJSON struct {
+	Source string   `json:"source"`
+	Target string   `json:"target"`
+	Weight *float64 `json:"weight,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler for Link. Link is encoded in
+// D3 compatible format, with attributes as additional fields. Weight is
+// omitted if it's equal to DefaultLinkWeight.
+func (l *Link) MarshalJSON() ([]byte, error) {
+	v := linkJSON{
+		Source: l.from,
+		Target: l.to,
+	}
+	if l.weight != DefaultLinkWeight {
+		v.Weight = &l.weight
+	}
+	return marshalWithAttrs(v, l.attrs, linkJSONFields)
+}
+
+// UnmarshalJSON implements json.Unmarshaler for Link. All fields except
+// source, target and weight are stored as link attributes.
+func (l *Link) UnmarshalJSON(data []byte) error {
+	var v linkJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	attrs, err := unmarshalAttrs(data, linkJSONFields)
+	if err != nil {
+		return err
+	}
+
+	*l = *NewLink(v.Source, v.Target)
+	if v.Weight != nil {
+		l.weight = *v.Weight
+	}
+	l.attrs = attrs
+	return nil
+}
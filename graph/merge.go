@@ -0,0 +1,169 @@
+package graph
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrDuplicateNode is returned by RejectDuplicateNodes policy.
+var ErrDuplicateNode = errors.New("duplicate node")
+
+// ErrDirectionMismatch is returned when combining directed and undirected graphs.
+var ErrDirectionMismatch = errors.New("can't combine directed and undirected graphs")
+
+// NodePolicy resolves conflict between nodes with the same ID, returning
+// the node to keep in the resulting graph.
+type NodePolicy[N Node] func(existing, other N) (N, error)
+
+// LinkPolicy resolves conflict between links with the same endpoints,
+// returning the link to keep in the resulting graph. Both links are copies,
+// so policy can modify them.
+type LinkPolicy func(existing, other *Link) *Link
+
+// MergeOptions specifies conflict resolution policies for Merge. Nil options
+// or nil policies mean keeping the first node and link.
+type MergeOptions[N Node] struct {
+	Nodes NodePolicy[N]
+	Links LinkPolicy
+}
+
+// KeepFirstNode is a NodePolicy which keeps the node seen first.
+func KeepFirstNode[N Node](existing, other N) (N, error) { return existing, nil }
+
+// KeepLastNode is a NodePolicy which keeps the node seen last.
+func KeepLastNode[N Node](existing, other N) (N, error) { return other, nil }
+
+// RejectDuplicateNodes is a NodePolicy which fails on any duplicate node.
+func RejectDuplicateNodes[N Node](existing, other N) (N, error) {
+	return existing, fmt.Errorf("%w: %s", ErrDuplicateNode, other.ID())
+}
+
+// KeepFirstLink is a LinkPolicy which keeps the link seen first.
+func KeepFirstLink(existing, other *Link) *Link { return existing }
+
+// KeepLastLink is a LinkPolicy which keeps the link seen last.
+func KeepLastLink(existing, other *Link) *Link { return other }
+
+// SumLinkWeights is a LinkPolicy which keeps the first link with weights
+// summed up, and attributes of the other link added to its attributes.
+func SumLinkWeights(existing, other *Link) *Link {
+	existing.weight += other.weight
+	for k, v := range other.attrs {
+		if _, ok := existing.attrs[k]; !ok {
+			existing.SetAttr(k, v)
+		}
+	}
+	return existing
+}
+
+// Merge combines graphs into the new one, containing all their nodes and
+// links. Nodes with the same ID and links with the same endpoints (including
+// duplicate links within a single graph) are resolved with the given policies,
+// so resulting graph has at most one link between any pair of nodes. Order of
+// nodes and links is preserved. Nodes are shared with the source graphs, and
+// links are copied.
+func Merge[N Node](opts *MergeOptions[N], graphs ...*TypedGraph[N]) (*TypedGraph[N], error) {
+	nodePolicy, linkPolicy := NodePolicy[N](KeepFirstNode[N]), LinkPolicy(KeepFirstLink)
+	if opts != nil && opts.Nodes != nil {
+		nodePolicy = opts.Nodes
+	}
+	if opts != nil && opts.Links != nil {
+		linkPolicy = opts.Links
+	}
+
+	m := newMerger[N](graphs)
+	if m.err != nil {
+		return nil, m.err
+	}
+	for _, g := range graphs {
+		for _, node := range g.nodes {
+			if err := m.addNode(node, nodePolicy); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for _, g := range graphs {
+		for _, link := range g.links {
+			m.addLink(link, linkPolicy)
+		}
+	}
+	return m.build()
+}
+
+// merger accumulates nodes and links for the combined graph.
+type merger[N Node] struct {
+	directed bool
+	err      error
+
+	nodes   []N
+	nodeIdx map[string]int
+	links   []*Link
+	linkIdx map[[2]string]int
+}
+
+func newMerger[N Node](graphs []*TypedGraph[N]) *merger[N] {
+	m := &merger[N]{
+		nodeIdx: make(map[string]int),
+		linkIdx: make(map[[2]string]int),
+	}
+	for i, g := range graphs {
+		if i == 0 {
+			m.directed = g.directed
+		} else if g.directed != m.directed {
+			m.err = ErrDirectionMismatch
+		}
+	}
+	return m
+}
+
+// addNode adds node, resolving conflict with the policy.
+func (m *merger[N]) addNode(node N, policy NodePolicy[N]) error {
+	idx, ok := m.nodeIdx[node.ID()]
+	if !ok {
+		m.nodeIdx[node.ID()] = len(m.nodes)
+		m.nodes = append(m.nodes, node)
+		return nil
+	}
+	resolved, err := policy(m.nodes[idx], node)
+	if err != nil {
+		return err
+	}
+	m.nodes[idx] = resolved
+	return nil
+}
+
+// addLink adds copy of the link, resolving conflict with the policy.
+func (m *merger[N]) addLink(link *Link, policy LinkPolicy) {
+	key := m.linkKey(link)
+	idx, ok := m.linkIdx[key]
+	if !ok {
+		m.linkIdx[key] = len(m.links)
+		m.links = append(m.links, link.Copy())
+		return
+	}
+	m.links[idx] = policy(m.links[idx], link.Copy())
+}
+
+// linkKey returns key of the link endpoints. For undirected graphs the order
+// of endpoints doesn't matter.
+func (m *merger[N]) linkKey(link *Link) [2]string {
+	if !m.directed && link.from > link.to {
+		return [2]string{link.to, link.from}
+	}
+	return [2]string{link.from, link.to}
+}
+
+// build creates the resulting graph.
+func (m *merger[N]) build() (*TypedGraph[N], error) {
+	var ret *TypedGraph[N]
+	if m.directed {
+		ret = NewDirectedTypedGraphMN[N](len(m.nodes), len(m.links))
+	} else {
+		ret = NewTypedGraphMN[N](len(m.nodes), len(m.links))
+	}
+	ret.AddNodes(m.nodes...)
+	if err := ret.AddLinks(m.links...); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
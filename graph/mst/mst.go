@@ -0,0 +1,116 @@
+// Package mst implements minimum spanning forest algorithms. Link weights are
+// used as costs, and links direction is ignored.
+//
+// Resulting graphs contain all nodes of the original graph and copies of the
+// spanning forest links, so they can be used for layout or exported directly.
+// Equal weights are resolved by link order, so both algorithms produce the
+// same forest.
+package mst
+
+import (
+	"container/heap"
+	"sort"
+
+	"github.com/divan/graphx/graph"
+)
+
+// Kruskal returns minimum spanning forest of the graph using Kruskal's algorithm.
+// It runs in O(M log M) time.
+func Kruskal(g *graph.Graph) *graph.Graph {
+	links := g.Links()
+	order := make([]int, len(links))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return links[order[a]].Weight() < links[order[b]].Weight()
+	})
+
+	uf := newUnionFind(g.NumNodes())
+	var tree []int
+	for _, idx := range order {
+		if uf.union(links[idx].FromIdx(), links[idx].ToIdx()) {
+			tree = append(tree, idx)
+		}
+	}
+	return forest(g, tree)
+}
+
+// Prim returns minimum spanning forest of the graph using Prim's algorithm,
+// growing tree from each node not yet covered. It runs in O(M log M) time.
+func Prim(g *graph.Graph) *graph.Graph {
+	links := g.Links()
+	n := g.NumNodes()
+
+	// link indices of each node, including duplicate links
+	adj := make([][]int, n)
+	for idx, link := range links {
+		from, to := link.FromIdx(), link.ToIdx()
+		adj[from] = append(adj[from], idx)
+		if from != to {
+			adj[to] = append(adj[to], idx)
+		}
+	}
+
+	inTree := make([]bool, n)
+	var tree []int
+	var q queue
+
+	// push adds links of the node u to the queue
+	push := func(u int) {
+		inTree[u] = true
+		for _, idx := range adj[u] {
+			v := links[idx].ToIdx()
+			if v == u {
+				v = links[idx].FromIdx()
+			}
+			if !inTree[v] {
+				heap.Push(&q, queueItem{idx: idx, to: v, weight: links[idx].Weight()})
+			}
+		}
+	}
+
+	for root := 0; root < n; root++ {
+		if inTree[root] {
+			continue
+		}
+		push(root)
+		for q.Len() > 0 {
+			item := heap.Pop(&q).(queueItem)
+			if inTree[item.to] {
+				continue
+			}
+			tree = append(tree, item.idx)
+			push(item.to)
+		}
+	}
+	return forest(g, tree)
+}
+
+// forest creates new graph with all nodes of g and copies of the given links.
+func forest(g *graph.Graph, indices []int) *graph.Graph {
+	var ret *graph.Graph
+	if g.Directed() {
+		ret = graph.NewDirectedGraphMN(g.NumNodes(), len(indices))
+	} else {
+		ret = graph.NewGraphMN(g.NumNodes(), len(indices))
+	}
+	ret.AddNodes(g.Nodes()...)
+
+	sort.Ints(indices)
+	links := g.Links()
+	for _, idx := range indices {
+		ret.AddLinks(links[idx].Copy())
+	}
+	return ret
+}
+
+// Weight returns total weight of the graph links, which is useful
+// for comparing spanning trees.
+func Weight(g *graph.Graph) float64 {
+	var sum float64
+	for _, link := range g.Links() {
+		sum += link.Weight()
+	}
+	return sum
+}
@@ -0,0 +1,102 @@
+package mst
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/divan/graphx/generation/basic"
+	"github.com/divan/graphx/graph"
+	"github.com/divan/graphx/graph/components"
+)
+
+// testGraph creates graph with two components: weighted square a-b-c-d with
+// diagonal a-c, and a single link e-f.
+func testGraph() *graph.Graph {
+	g := graph.NewGraph()
+	for _, id := range []string{"a", "b", "c", "d", "e", "f"} {
+		g.AddNode(graph.NewBasicNode(id))
+	}
+	g.AddWeightedLink("a", "b", 1)
+	g.AddWeightedLink("b", "c", 4)
+	g.AddWeightedLink("c", "d", 2)
+	g.AddWeightedLink("d", "a", 5)
+	g.AddWeightedLink("a", "c", 3)
+	g.AddWeightedLink("e", "f", 7)
+	return g
+}
+
+// linkPairs returns from-to pairs of the graph links.
+func linkPairs(g *graph.Graph) [][2]string {
+	var ret [][2]string
+	for _, l := range g.Links() {
+		ret = append(ret, [2]string{l.From(), l.To()})
+	}
+	return ret
+}
+
+func TestMST(t *testing.T) {
+	expected := [][2]string{{"a", "b"}, {"c", "d"}, {"a", "c"}, {"e", "f"}}
+	for name, fn := range map[string]func(*graph.Graph) *graph.Graph{"Kruskal": Kruskal, "Prim": Prim} {
+		g := testGraph()
+		tree := fn(g)
+		if tree.NumNodes() != g.NumNodes() {
+			t.Fatalf("%s: expected %d nodes, but got %d", name, g.NumNodes(), tree.NumNodes())
+		}
+		if got := linkPairs(tree); !reflect.DeepEqual(got, expected) {
+			t.Fatalf("%s: expected links %v, but got %v", name, expected, got)
+		}
+		if w := Weight(tree); w != 13 {
+			t.Fatalf("%s: expected weight %v, but got %v", name, 13, w)
+		}
+		// original graph is untouched
+		if g.NumLinks() != 6 {
+			t.Fatalf("%s: original graph modified", name)
+		}
+	}
+}
+
+func TestMSTAttrs(t *testing.T) {
+	g := graph.NewDirectedGraph()
+	g.AddNode(graph.NewBasicNode("a"))
+	g.AddNode(graph.NewBasicNode("b"))
+	link := graph.NewWeightedLink("b", "a", 2)
+	link.SetAttr("label", "x")
+	g.AddLinks(link)
+	g.AddWeightedLink("a", "b", 3)
+
+	tree := Prim(g)
+	if !tree.Directed() || tree.NumLinks() != 1 {
+		t.Fatalf("Expected directed tree with %d link, but got %d", 1, tree.NumLinks())
+	}
+	l := tree.Links()[0]
+	if l.From() != "b" || l.To() != "a" {
+		t.Fatalf("Expected link direction to be preserved, but got %s -> %s", l.From(), l.To())
+	}
+	if v, _ := l.Attr("label"); v != "x" {
+		t.Fatalf("Expected link attribute to be copied, but got %v", v)
+	}
+	l.SetAttr("label", "y")
+	if v, _ := link.Attr("label"); v != "x" {
+		t.Fatalf("Expected original link attributes to be untouched, but got %v", v)
+	}
+}
+
+func TestKruskalPrimEqual(t *testing.T) {
+	g := basic.NewKingGeneratorN(400).Generate()
+	r := rand.New(rand.NewSource(1))
+	for _, l := range g.Links() {
+		l.SetWeight(float64(r.Intn(10)))
+	}
+
+	k, p := Kruskal(g), Prim(g)
+	if !reflect.DeepEqual(linkPairs(k), linkPairs(p)) {
+		t.Fatalf("Expected Kruskal and Prim to produce the same forest")
+	}
+	if k.NumLinks() != g.NumNodes()-1 {
+		t.Fatalf("Expected spanning tree to have %d links, but got %d", g.NumNodes()-1, k.NumLinks())
+	}
+	if components.Connected(k).Count() != 1 {
+		t.Fatalf("Expected spanning tree to be connected")
+	}
+}
@@ -0,0 +1,28 @@
+package mst
+
+// queueItem represents candidate link to the node outside of the tree.
+type queueItem struct {
+	idx    int // link index
+	to     int // node index
+	weight float64
+}
+
+// queue implements min-priority queue for heap.Interface. Links with equal
+// weights are ordered by their indices.
+type queue []queueItem
+
+func (q queue) Len() int { return len(q) }
+func (q queue) Less(i, j int) bool {
+	if q[i].weight != q[j].weight {
+		return q[i].weight < q[j].weight
+	}
+	return q[i].idx < q[j].idx
+}
+func (q queue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *queue) Push(x interface{}) { *q = append(*q, x.(queueItem)) }
+func (q *queue) Pop() interface{} {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return item
+}
@@ -1,5 +1,7 @@
 package graph
 
+import "encoding/json"
+
 // Node defines the graph node. Any type implementing this
 // interface can be used as a graph node.
 type Node interface {
@@ -11,29 +13,132 @@ type GroupedNode interface {
 	Group() int
 }
 
+// GroupSetter represents node which 'group' attribute can be changed.
+type GroupSetter interface {
+	SetGroup(group int)
+}
+
 // WeightedNode represents node that have 'weight' attribute.
 type WeightedNode interface {
 	Weight() int
 }
 
+// AttributedNode represents node that have arbitrary key/value attributes.
+type AttributedNode interface {
+	Attrs() Attrs
+}
+
 // AddNode adds new node to graph.
-func (g *Graph) AddNode(node Node) {
+func (g *TypedGraph[N]) AddNode(node N) {
 	g.nodes = append(g.nodes, node)
+	g.adj = append(g.adj, nil)
+	if g.directed {
+		g.inAdj = append(g.inAdj, nil)
+	}
 	g.cacheNode(node, len(g.nodes)-1)
 }
 
 // AddNodes adds new nodes to graph.
-func (g *Graph) AddNodes(nodes ...Node) {
+func (g *TypedGraph[N]) AddNodes(nodes ...N) {
 	for _, node := range nodes {
 		g.AddNode(node)
 	}
 }
 
+// ReplaceNode replaces node having the same ID with the given one,
+// keeping its index and links.
+func (g *TypedGraph[N]) ReplaceNode(node N) error {
+	idx, err := g.NodeByID(node.ID())
+	if err != nil {
+		return err
+	}
+	g.nodes[idx] = node
+	return nil
+}
+
+// RemoveNode removes node with the given ID from the graph, along with
+// all links connected to it. Indices of the remaining nodes and links are
+// updated accordingly.
+func (g *TypedGraph[N]) RemoveNode(id string) error {
+	return g.RemoveNodes(id)
+}
+
+// RemoveNodes removes nodes with given IDs from the graph, along with all
+// links connected to them. It's more efficient than calling RemoveNode
+// for each node, as indices are recalculated only once.
+// If any of the IDs is not found, graph is left unmodified.
+func (g *TypedGraph[N]) RemoveNodes(ids ...string) error {
+	removed := make(map[int]bool, len(ids))
+	for _, id := range ids {
+		idx, err := g.NodeByID(id)
+		if err != nil {
+			return err
+		}
+		removed[idx] = true
+	}
+	if len(removed) == 0 {
+		return nil
+	}
+
+	// remove incident links first, as they still use old indices
+	links := g.links[:0]
+	for _, link := range g.links {
+		if removed[link.fromIdx] || removed[link.toIdx] {
+			continue
+		}
+		links = append(links, link)
+	}
+	for i := len(links); i < len(g.links); i++ {
+		g.links[i] = nil // let GC collect removed links
+	}
+	g.links = links
+
+	nodes := g.nodes[:0]
+	for i, node := range g.nodes {
+		if removed[i] {
+			continue
+		}
+		nodes = append(nodes, node)
+	}
+	var zero N
+	for i := len(nodes); i < len(g.nodes); i++ {
+		g.nodes[i] = zero
+	}
+	g.nodes = nodes
+
+	// recalculate indices and caches
+	g.UpdateCache()
+	return nil
+}
+
+// SetGroups sets group of each node implementing GroupSetter to the value
+// from groups keyed by node ID, like community or color index. Nodes missing
+// from groups are left unchanged. It returns number of updated nodes.
+func SetGroups(g *Graph, groups map[string]int) int {
+	var count int
+	for _, node := range g.Nodes() {
+		gs, ok := node.(GroupSetter)
+		if !ok {
+			continue
+		}
+		group, ok := groups[node.ID()]
+		if !ok {
+			continue
+		}
+		gs.SetGroup(group)
+		count++
+	}
+	return count
+}
+
 // BasicNode represents basic built-in node type for simple cases.
+// All JSON fields except `id`, `group` and `weight` are preserved
+// as node attributes.
 type BasicNode struct {
 	ID_     string `json:"id"`
 	Group_  int    `json:"group,omitempty"`
 	Weight_ int    `json:"weight,omitempty"`
+	Attrs_  Attrs  `json:"-"`
 }
 
 // ID implements Node for BasicNode.
@@ -42,9 +147,54 @@ func (b *BasicNode) ID() string { return b.ID_ }
 // Group implements GroupNode for BasicNode.
 func (b *BasicNode) Group() int { return b.Group_ }
 
+// SetGroup implements GroupSetter for BasicNode.
+func (b *BasicNode) SetGroup(group int) { b.Group_ = group }
+
 // Weight implements WeightedNode for BasicNode.
 func (b *BasicNode) Weight() int { return b.Weight_ }
 
+// Attrs implements AttributedNode for BasicNode.
+func (b *BasicNode) Attrs() Attrs { return b.Attrs_ }
+
+// Attr returns node's attribute value by its key.
+func (b *BasicNode) Attr(key string) (interface{}, bool) {
+	v, ok := b.Attrs_[key]
+	return v, ok
+}
+
+// SetAttr sets node's attribute value.
+func (b *BasicNode) SetAttr(key string, value interface{}) {
+	if b.Attrs_ == nil {
+		b.Attrs_ = make(Attrs)
+	}
+	b.Attrs_[key] = value
+}
+
+// basicNodeJSONFields lists BasicNode fields, which are not stored as attributes.
+var basicNodeJSONFields = map[string]bool{"id": true, "group": true, "weight": true}
+
+// MarshalJSON implements json.Marshaler for BasicNode. Attributes are
+// encoded as additional fields.
+func (b *BasicNode) MarshalJSON() ([]byte, error) {
+	type node BasicNode // prevent recursion
+	return marshalWithAttrs((*node)(b), b.Attrs_, basicNodeJSONFields)
+}
+
+// UnmarshalJSON implements json.Unmarshaler for BasicNode. Unknown fields
+// are stored as node attributes.
+func (b *BasicNode) UnmarshalJSON(data []byte) error {
+	type node BasicNode // prevent recursion
+	if err := json.Unmarshal(data, (*node)(b)); err != nil {
+		return err
+	}
+	attrs, err := unmarshalAttrs(data, basicNodeJSONFields)
+	if err != nil {
+		return err
+	}
+	b.Attrs_ = attrs
+	return nil
+}
+
 // NewBasicNode creaates a new basic node with given ID.
 func NewBasicNode(id string) *BasicNode {
 	return &BasicNode{
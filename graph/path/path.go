@@ -0,0 +1,252 @@
+// Package path implements shortest path algorithms over graph.Graph: BFS for
+// unweighted graphs, Dijkstra for graphs with link weights and A* with optional
+// heuristic, like Euclidean distance between nodes' layout positions.
+package path
+
+import (
+	"container/heap"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/divan/graphx/graph"
+	"github.com/divan/graphx/graph/traverse"
+	"github.com/divan/graphx/layout"
+)
+
+// ErrNoPath is returned when there is no path between nodes.
+var ErrNoPath = errors.New("path not found")
+
+// Path represents path between two nodes.
+type Path struct {
+	Nodes    []string // IDs of nodes on the path, including source and target
+	Distance float64  // sum of link weights, or number of hops for unweighted paths
+}
+
+// Tree represents single-source shortest paths.
+type Tree struct {
+	Source string
+	Dist   map[string]float64 // distance to each reachable node
+	Prev   map[string]string  // previous node on the shortest path, except source
+}
+
+// PathTo returns shortest path from the tree source to the node with given ID.
+func (t *Tree) PathTo(id string) (*Path, error) {
+	dist, ok := t.Dist[id]
+	if !ok {
+		return nil, ErrNoPath
+	}
+
+	var nodes []string
+	for cur := id; ; cur = t.Prev[cur] {
+		nodes = append(nodes, cur)
+		if cur == t.Source {
+			break
+		}
+	}
+	reverse(nodes)
+	return &Path{Nodes: nodes, Distance: dist}, nil
+}
+
+// Heuristic estimates distance between nodes with given indices for A* search.
+// It should never overestimate the actual distance, otherwise found path may be
+// not the shortest one.
+type Heuristic func(from, to int) float64
+
+// EuclideanHeuristic returns heuristic, which estimates distance as Euclidean
+// distance between node positions multiplied by scale. Positions must be in the
+// same order as graph nodes, like ones returned by layout.PositionsSlice.
+// Scale should be chosen so that scaled distance doesn't exceed links weights.
+func EuclideanHeuristic(positions []*layout.Position, scale float64) Heuristic {
+	return func(from, to int) float64 {
+		a, b := positions[from], positions[to]
+		dx, dy, dz := a.X-b.X, a.Y-b.Y, a.Z-b.Z
+		return scale * math.Sqrt(dx*dx+dy*dy+dz*dz)
+	}
+}
+
+// Unweighted returns shortest path between nodes in terms of number of hops.
+func Unweighted(g *graph.Graph, from, to string) (*Path, error) {
+	if _, err := g.NodeByID(to); err != nil {
+		return nil, err
+	}
+	res, err := traverse.BFS(g, from, &traverse.Options{
+		Visit: func(id string, _ int) bool { return id != to },
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := res.PathTo(to)
+	if nodes == nil {
+		return nil, ErrNoPath
+	}
+	return &Path{Nodes: nodes, Distance: float64(len(nodes) - 1)}, nil
+}
+
+// UnweightedFrom returns shortest paths in terms of number of hops from the node
+// with given ID to all reachable nodes.
+func UnweightedFrom(g *graph.Graph, from string) (*Tree, error) {
+	res, err := traverse.BFS(g, from, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &Tree{
+		Source: from,
+		Dist:   make(map[string]float64, len(res.Depth)),
+		Prev:   res.Parent,
+	}
+	for id, d := range res.Depth {
+		t.Dist[id] = float64(d)
+	}
+	return t, nil
+}
+
+// Dijkstra returns shortest path between nodes using link weights as distances.
+// Weights must be non-negative.
+func Dijkstra(g *graph.Graph, from, to string) (*Path, error) {
+	return AStar(g, from, to, nil)
+}
+
+// DijkstraFrom returns shortest paths from the node with given ID to all reachable
+// nodes using link weights as distances. Weights must be non-negative.
+func DijkstraFrom(g *graph.Graph, from string) (*Tree, error) {
+	src, err := g.NodeByID(from)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := search(g, src, -1, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &Tree{
+		Source: from,
+		Dist:   make(map[string]float64),
+		Prev:   make(map[string]string),
+	}
+	for i, d := range s.dist {
+		if math.IsInf(d, 1) {
+			continue
+		}
+		t.Dist[s.ids[i]] = d
+		if s.prev[i] != -1 {
+			t.Prev[s.ids[i]] = s.ids[s.prev[i]]
+		}
+	}
+	return t, nil
+}
+
+// AStar returns shortest path between nodes using link weights as distances and
+// h as an estimate of remaining distance. If h is nil, it's equal to Dijkstra.
+// Weights must be non-negative.
+func AStar(g *graph.Graph, from, to string, h Heuristic) (*Path, error) {
+	src, err := g.NodeByID(from)
+	if err != nil {
+		return nil, err
+	}
+	dst, err := g.NodeByID(to)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := search(g, src, dst, h)
+	if err != nil {
+		return nil, err
+	}
+	if math.IsInf(s.dist[dst], 1) {
+		return nil, ErrNoPath
+	}
+
+	var nodes []string
+	for cur := dst; cur != -1; cur = s.prev[cur] {
+		nodes = append(nodes, s.ids[cur])
+	}
+	reverse(nodes)
+	return &Path{Nodes: nodes, Distance: s.dist[dst]}, nil
+}
+
+// searchState holds node IDs, distances and previous nodes indices.
+type searchState struct {
+	ids  []string
+	dist []float64
+	prev []int
+}
+
+// search runs A* (or Dijkstra if h is nil) from src. If dst is -1, it
+// finds paths to all reachable nodes.
+func search(g *graph.Graph, src, dst int, h Heuristic) (*searchState, error) {
+	n := g.NumNodes()
+	s := &searchState{
+		ids:  make([]string, n),
+		dist: make([]float64, n),
+		prev: make([]int, n),
+	}
+	for i, node := range g.Nodes() {
+		s.ids[i] = node.ID()
+	}
+
+	// outgoing link indices of each node, including duplicate links,
+	// as the cheapest of them should be used
+	links := g.Links()
+	adj := make([][]int, n)
+	for idx, link := range links {
+		from, to := link.FromIdx(), link.ToIdx()
+		adj[from] = append(adj[from], idx)
+		if !g.Directed() && from != to {
+			adj[to] = append(adj[to], idx)
+		}
+	}
+
+	for i := range s.dist {
+		s.dist[i] = math.Inf(1)
+		s.prev[i] = -1
+	}
+	estimate := func(idx int) float64 {
+		if h == nil || dst == -1 {
+			return 0
+		}
+		return h(idx, dst)
+	}
+
+	done := make([]bool, n)
+	s.dist[src] = 0
+	pq := &queue{{idx: src, priority: estimate(src)}}
+	for pq.Len() > 0 {
+		item := heap.Pop(pq).(queueItem)
+		u := item.idx
+		if done[u] {
+			continue
+		}
+		done[u] = true
+		if u == dst {
+			break
+		}
+
+		for _, idx := range adj[u] {
+			v := links[idx].ToIdx()
+			if v == u {
+				v = links[idx].FromIdx()
+			}
+			w := links[idx].Weight()
+			if w < 0 {
+				return nil, fmt.Errorf("negative link weight %v between %s and %s", w, s.ids[u], s.ids[v])
+			}
+			if d := s.dist[u] + w; d < s.dist[v] {
+				s.dist[v] = d
+				s.prev[v] = u
+				heap.Push(pq, queueItem{idx: v, priority: d + estimate(v)})
+			}
+		}
+	}
+	return s, nil
+}
+
+// reverse reverses slice in place.
+func reverse(s []string) {
+	for i, j := 0, len(s)-1; i < j; i, j = i+1, j-1 {
+		s[i], s[j] = s[j], s[i]
+	}
+}
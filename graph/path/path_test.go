@@ -0,0 +1,150 @@
+package path
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/divan/graphx/generation/basic"
+	"github.com/divan/graphx/graph"
+	"github.com/divan/graphx/layout"
+)
+
+// weightedGraph creates graph where direct link a-d is heavier
+// than path through b and c.
+func weightedGraph() *graph.Graph {
+	g := graph.NewGraph()
+	for _, id := range []string{"a", "b", "c", "d", "e"} {
+		g.AddNode(graph.NewBasicNode(id))
+	}
+	g.AddWeightedLink("a", "d", 10)
+	g.AddWeightedLink("a", "b", 1)
+	g.AddWeightedLink("b", "c", 2)
+	g.AddWeightedLink("c", "d", 3)
+	return g
+}
+
+func TestUnweighted(t *testing.T) {
+	g := weightedGraph()
+	p, err := Unweighted(g, "a", "d")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(p.Nodes, []string{"a", "d"}) || p.Distance != 1 {
+		t.Fatalf("Unexpected path: %v (%v)", p.Nodes, p.Distance)
+	}
+
+	_, err = Unweighted(g, "a", "e")
+	if err != ErrNoPath {
+		t.Fatalf("Expected ErrNoPath, but got %v", err)
+	}
+
+	tree, err := UnweightedFrom(g, "b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tree.Dist["d"] != 2 {
+		t.Fatalf("Expected distance %v, but got %v", 2, tree.Dist["d"])
+	}
+}
+
+func TestDijkstra(t *testing.T) {
+	g := weightedGraph()
+	p, err := Dijkstra(g, "d", "a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(p.Nodes, []string{"d", "c", "b", "a"}) || p.Distance != 6 {
+		t.Fatalf("Unexpected path: %v (%v)", p.Nodes, p.Distance)
+	}
+
+	tree, err := DijkstraFrom(g, "a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tree.Dist) != 4 || tree.Dist["c"] != 3 {
+		t.Fatalf("Unexpected distances: %v", tree.Dist)
+	}
+	p, err = tree.PathTo("d")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(p.Nodes, []string{"a", "b", "c", "d"}) {
+		t.Fatalf("Unexpected path: %v", p.Nodes)
+	}
+	if _, err := tree.PathTo("e"); err != ErrNoPath {
+		t.Fatalf("Expected ErrNoPath, but got %v", err)
+	}
+
+	g.AddWeightedLink("d", "e", -1)
+	if _, err := Dijkstra(g, "a", "e"); err == nil {
+		t.Fatalf("Expected error for negative weight")
+	}
+}
+
+func TestParallelLinks(t *testing.T) {
+	g := graph.NewGraph()
+	g.AddNode(graph.NewBasicNode("a"))
+	g.AddNode(graph.NewBasicNode("b"))
+	g.AddWeightedLink("a", "b", 5)
+	g.AddWeightedLink("a", "b", 1)
+
+	p, err := Dijkstra(g, "b", "a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Distance != 1 {
+		t.Fatalf("Expected distance %v, but got %v", 1, p.Distance)
+	}
+	tree, err := DijkstraFrom(g, "a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tree.Dist["b"] != 1 {
+		t.Fatalf("Expected distance %v, but got %v", 1, tree.Dist["b"])
+	}
+}
+
+func TestDirected(t *testing.T) {
+	g := graph.NewDirectedGraph()
+	for _, id := range []string{"a", "b", "c"} {
+		g.AddNode(graph.NewBasicNode(id))
+	}
+	g.AddWeightedLink("a", "b", 1)
+	g.AddWeightedLink("b", "c", 1)
+	g.AddWeightedLink("c", "a", 1)
+
+	p, err := Dijkstra(g, "a", "c")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Distance != 2 {
+		t.Fatalf("Expected distance %v, but got %v", 2, p.Distance)
+	}
+	p, err = Unweighted(g, "c", "b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(p.Nodes) != 3 {
+		t.Fatalf("Expected path to respect direction, but got %v", p.Nodes)
+	}
+}
+
+func TestAStar(t *testing.T) {
+	rows, cols := 10, 10
+	g := basic.NewGrid2DGenerator(rows, cols).Generate()
+	positions := make([]*layout.Position, rows*cols)
+	for i := range positions {
+		positions[i] = &layout.Position{X: float64(i % cols), Y: float64(i / cols)}
+	}
+
+	p, err := AStar(g, "0", "99", EuclideanHeuristic(positions, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Distance != 18 || len(p.Nodes) != 19 {
+		t.Fatalf("Expected distance %v, but got %v", 18, p.Distance)
+	}
+	if p.Nodes[0] != "0" || p.Nodes[18] != "99" {
+		t.Fatalf("Unexpected path: %v", p.Nodes)
+	}
+}
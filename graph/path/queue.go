@@ -0,0 +1,21 @@
+package path
+
+// queueItem represents node index with its priority in the queue.
+type queueItem struct {
+	idx      int
+	priority float64
+}
+
+// queue implements min-priority queue for heap.Interface.
+type queue []queueItem
+
+func (q queue) Len() int            { return len(q) }
+func (q queue) Less(i, j int) bool  { return q[i].priority < q[j].priority }
+func (q queue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *queue) Push(x interface{}) { *q = append(*q, x.(queueItem)) }
+func (q *queue) Pop() interface{} {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return item
+}
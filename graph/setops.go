@@ -0,0 +1,74 @@
+package graph
+
+// Set operations compare nodes by their IDs, and links by their endpoints
+// IDs (ignoring order for undirected graphs). Nodes and links of the first
+// graph take precedence. Nodes are shared with the source graphs, and links
+// are copied.
+
+// Union returns graph with nodes and links of both graphs. It's the same
+// as Merge with default policies.
+func Union[N Node](a, b *TypedGraph[N]) (*TypedGraph[N], error) {
+	return Merge[N](nil, a, b)
+}
+
+// Intersection returns graph with nodes and links present in both graphs.
+func Intersection[N Node](a, b *TypedGraph[N]) (*TypedGraph[N], error) {
+	m := newMerger[N]([]*TypedGraph[N]{a, b})
+	if m.err != nil {
+		return nil, m.err
+	}
+	for _, node := range a.nodes {
+		if _, err := b.NodeByID(node.ID()); err == nil {
+			m.addNode(node, KeepFirstNode[N])
+		}
+	}
+	for _, link := range a.links {
+		if b.LinkExists(link.from, link.to) {
+			m.addLink(link, KeepFirstLink)
+		}
+	}
+	return m.build()
+}
+
+// Difference returns graph with all nodes of a and links of a, which are
+// not present in b.
+func Difference[N Node](a, b *TypedGraph[N]) (*TypedGraph[N], error) {
+	m := newMerger[N]([]*TypedGraph[N]{a, b})
+	if m.err != nil {
+		return nil, m.err
+	}
+	for _, node := range a.nodes {
+		m.addNode(node, KeepFirstNode[N])
+	}
+	for _, link := range a.links {
+		if !b.LinkExists(link.from, link.to) {
+			m.addLink(link, KeepFirstLink)
+		}
+	}
+	return m.build()
+}
+
+// SymmetricDifference returns graph with nodes of both graphs and links
+// present in exactly one of them.
+func SymmetricDifference[N Node](a, b *TypedGraph[N]) (*TypedGraph[N], error) {
+	m := newMerger[N]([]*TypedGraph[N]{a, b})
+	if m.err != nil {
+		return nil, m.err
+	}
+	for _, g := range []*TypedGraph[N]{a, b} {
+		for _, node := range g.nodes {
+			m.addNode(node, KeepFirstNode[N])
+		}
+	}
+	for _, link := range a.links {
+		if !b.LinkExists(link.from, link.to) {
+			m.addLink(link, KeepFirstLink)
+		}
+	}
+	for _, link := range b.links {
+		if !a.LinkExists(link.from, link.to) {
+			m.addLink(link, KeepFirstLink)
+		}
+	}
+	return m.build()
+}
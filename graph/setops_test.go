@@ -0,0 +1,136 @@
+package graph
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// pairsGraph creates undirected graph with given links, adding nodes as needed.
+func pairsGraph(links ...[2]string) *Graph {
+	g := NewGraph()
+	for _, l := range links {
+		for _, id := range l {
+			if _, err := g.NodeByID(id); err != nil {
+				g.AddNode(NewBasicNode(id))
+			}
+		}
+		g.AddLink(l[0], l[1])
+	}
+	return g
+}
+
+// linkPairs returns from-to pairs of the graph links.
+func linkPairs(g *Graph) [][2]string {
+	var ret [][2]string
+	for _, l := range g.Links() {
+		ret = append(ret, [2]string{l.From(), l.To()})
+	}
+	return ret
+}
+
+func TestSetOperations(t *testing.T) {
+	a := pairsGraph([2]string{"a", "b"}, [2]string{"b", "c"})
+	b := pairsGraph([2]string{"c", "b"}, [2]string{"c", "d"})
+
+	tests := []struct {
+		name  string
+		op    func(a, b *Graph) (*Graph, error)
+		nodes []string
+		links [][2]string
+	}{
+		{"Union", Union[Node], []string{"a", "b", "c", "d"}, [][2]string{{"a", "b"}, {"b", "c"}, {"c", "d"}}},
+		{"Intersection", Intersection[Node], []string{"b", "c"}, [][2]string{{"b", "c"}}},
+		{"Difference", Difference[Node], []string{"a", "b", "c"}, [][2]string{{"a", "b"}}},
+		{"SymmetricDifference", SymmetricDifference[Node], []string{"a", "b", "c", "d"}, [][2]string{{"a", "b"}, {"c", "d"}}},
+	}
+	for _, test := range tests {
+		g, err := test.op(a, b)
+		if err != nil {
+			t.Fatalf("%s failed: %v", test.name, err)
+		}
+		var nodes []string
+		for _, node := range g.Nodes() {
+			nodes = append(nodes, node.ID())
+		}
+		if !reflect.DeepEqual(nodes, test.nodes) {
+			t.Fatalf("%s: expected nodes %v, but got %v", test.name, test.nodes, nodes)
+		}
+		if got := linkPairs(g); !reflect.DeepEqual(got, test.links) {
+			t.Fatalf("%s: expected links %v, but got %v", test.name, test.links, got)
+		}
+		checkConsistency(t, g)
+	}
+
+	if _, err := Union(a, NewDirectedGraph()); !errors.Is(err, ErrDirectionMismatch) {
+		t.Fatalf("Expected %v, but got %v", ErrDirectionMismatch, err)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	a := pairsGraph([2]string{"a", "b"})
+	a.Links()[0].SetAttr("src", "a")
+	b := NewGraph()
+	b.AddNode(&BasicNode{ID_: "a", Group_: 1})
+	b.AddNode(NewBasicNode("b"))
+	link := NewWeightedLink("b", "a", 2)
+	link.SetAttr("src", "b")
+	link.SetAttr("extra", true)
+	b.AddLinks(link)
+
+	// defaults keep the first node and link
+	g, err := Merge[Node](nil, a, b)
+	if err != nil {
+		t.Fatalf("Merge failed: %v", err)
+	}
+	if g.nodes[0].(*BasicNode).Group() != 0 || g.links[0].Weight() != 1 {
+		t.Fatalf("Expected first node and link to be kept")
+	}
+
+	g, err = Merge(&MergeOptions[Node]{Nodes: KeepLastNode[Node], Links: SumLinkWeights}, a, b)
+	if err != nil {
+		t.Fatalf("Merge failed: %v", err)
+	}
+	if g.nodes[0] != b.nodes[0] {
+		t.Fatalf("Expected node payload of the last graph to be kept")
+	}
+	l := g.links[0]
+	if g.NumLinks() != 1 || l.Weight() != 3 {
+		t.Fatalf("Expected single link with weight %v, but got %v", 3, g.Links())
+	}
+	if src, _ := l.Attr("src"); src != "a" {
+		t.Fatalf("Expected existing attribute to be kept, but got %v", src)
+	}
+	if _, ok := l.Attr("extra"); !ok {
+		t.Fatalf("Expected other link attributes to be merged")
+	}
+	// sources are untouched
+	if a.links[0].Weight() != 1 || len(a.links[0].Attrs()) != 1 {
+		t.Fatalf("Expected source links to be untouched")
+	}
+
+	g, _ = Merge(&MergeOptions[Node]{Links: KeepLastLink}, a, b)
+	if g.links[0].From() != "b" || g.links[0].Weight() != 2 {
+		t.Fatalf("Expected last link to be kept, but got %v", g.Links())
+	}
+
+	_, err = Merge(&MergeOptions[Node]{Nodes: RejectDuplicateNodes[Node]}, a, b)
+	if !errors.Is(err, ErrDuplicateNode) {
+		t.Fatalf("Expected %v, but got %v", ErrDuplicateNode, err)
+	}
+}
+
+func TestMergeTyped(t *testing.T) {
+	type host struct{ BasicNode }
+	a := NewTypedGraph[*host]()
+	a.AddNode(&host{BasicNode{ID_: "x"}})
+	b := NewTypedGraph[*host]()
+	b.AddNode(&host{BasicNode{ID_: "y"}})
+	g, err := Merge[*host](nil, a, b)
+	if err != nil {
+		t.Fatalf("Merge failed: %v", err)
+	}
+	if g.NumNodes() != 2 || g.Nodes()[1] != b.Nodes()[0] {
+		t.Fatalf("Expected typed nodes to be preserved")
+	}
+}
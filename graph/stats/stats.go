@@ -0,0 +1,186 @@
+// Package stats implements graph summary report: counts, density, degree
+// distribution, components, diameter, clustering and assortativity.
+package stats
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/divan/graphx/graph"
+	"github.com/divan/graphx/graph/clustering"
+	"github.com/divan/graphx/graph/components"
+	"github.com/divan/graphx/graph/distance"
+)
+
+// Default values for Options.
+const (
+	DefaultExactLimit = 1000
+	DefaultSamples    = 16
+)
+
+// Options specifies parameters for the report. Nil options or zero values
+// mean defaults.
+type Options struct {
+	// ExactLimit is the maximum number of nodes for which diameter is
+	// computed exactly.
+	ExactLimit int
+	// Samples sets number of BFS runs for diameter estimation on larger graphs.
+	Samples int
+	// Rand is a source of randomness for sampling. If nil, deterministic
+	// source is used.
+	Rand *rand.Rand
+}
+
+// withDefaults returns copy of options with defaults filled.
+func (o *Options) withDefaults() Options {
+	var ret Options
+	if o != nil {
+		ret = *o
+	}
+	if ret.ExactLimit <= 0 {
+		ret.ExactLimit = DefaultExactLimit
+	}
+	if ret.Samples <= 0 {
+		ret.Samples = DefaultSamples
+	}
+	return ret
+}
+
+// Report represents graph summary. Counts, density, degrees and assortativity
+// take every link into account, including duplicate ones, so density of
+// graphs with duplicate links may exceed 1. Self loops add two to the node
+// degree. For directed graphs, node degree is the sum of its in- and
+// out-degrees.
+type Report struct {
+	Nodes    int     `json:"nodes"`
+	Links    int     `json:"links"`
+	Directed bool    `json:"directed"`
+	Density  float64 `json:"density"`
+
+	MinDegree int     `json:"min_degree"`
+	MaxDegree int     `json:"max_degree"`
+	AvgDegree float64 `json:"avg_degree"`
+	// DegreeHistogram holds number of nodes for each degree value,
+	// indexed by degree.
+	DegreeHistogram []int `json:"degree_histogram"`
+
+	Components       int `json:"components"`
+	LargestComponent int `json:"largest_component"`
+
+	Diameter int `json:"diameter"`
+	// DiameterExact is false if diameter is a sampled lower bound estimate.
+	DiameterExact bool `json:"diameter_exact"`
+
+	Clustering    float64 `json:"clustering"`
+	Transitivity  float64 `json:"transitivity"`
+	Assortativity float64 `json:"assortativity"`
+}
+
+// Analyze calculates summary report for the graph.
+func Analyze(g *graph.Graph, opts *Options) *Report {
+	o := opts.withDefaults()
+	n, m := g.NumNodes(), g.NumLinks()
+	deg := degrees(g)
+
+	r := &Report{
+		Nodes:         n,
+		Links:         m,
+		Directed:      g.Directed(),
+		Density:       density(n, m, g.Directed()),
+		Clustering:    clustering.Average(g),
+		Transitivity:  clustering.Transitivity(g),
+		Assortativity: assortativity(g, deg),
+	}
+
+	if n > 0 {
+		r.MinDegree = deg[0]
+	}
+	var sum int
+	for _, d := range deg {
+		sum += d
+		if d < r.MinDegree {
+			r.MinDegree = d
+		}
+		if d > r.MaxDegree {
+			r.MaxDegree = d
+		}
+	}
+	if n > 0 {
+		r.AvgDegree = float64(sum) / float64(n)
+		r.DegreeHistogram = make([]int, r.MaxDegree+1)
+	}
+	for _, d := range deg {
+		r.DegreeHistogram[d]++
+	}
+
+	comps := components.Connected(g)
+	r.Components = comps.Count()
+	r.LargestComponent = len(comps.Largest())
+
+	dopts := &distance.Options{Rand: o.Rand}
+	r.DiameterExact = n <= o.ExactLimit
+	if !r.DiameterExact {
+		dopts.Samples = o.Samples
+	}
+	r.Diameter = distance.Diameter(g, dopts)
+
+	return r
+}
+
+// Assortativity returns degree assortativity coefficient: Pearson correlation
+// of degrees of linked nodes. It's positive if high-degree nodes tend to link
+// to each other, and negative if they tend to link to low-degree nodes.
+// Links direction is ignored, and duplicate links are counted as separate
+// ones. For graphs where it's undefined (e.g. regular graphs) zero is returned.
+func Assortativity(g *graph.Graph) float64 {
+	return assortativity(g, degrees(g))
+}
+
+// assortativity calculates assortativity for the given node degrees.
+func assortativity(g *graph.Graph, deg []int) float64 {
+	// every link is counted in both directions, so both ends have the same
+	// degree distribution
+	var count, sum, sumSq, sumProd float64
+	for _, l := range g.Links() {
+		du, dv := float64(deg[l.FromIdx()]), float64(deg[l.ToIdx()])
+		count += 2
+		sum += du + dv
+		sumSq += du*du + dv*dv
+		sumProd += 2 * du * dv
+	}
+	if count == 0 {
+		return 0
+	}
+	mean := sum / count
+	variance := sumSq/count - mean*mean
+	if variance < 1e-12 {
+		return 0
+	}
+	r := (sumProd/count - mean*mean) / variance
+	if math.IsNaN(r) {
+		return 0
+	}
+	return r
+}
+
+// degrees returns degree of each node index, counting every link.
+func degrees(g *graph.Graph) []int {
+	ret := make([]int, g.NumNodes())
+	for _, l := range g.Links() {
+		ret[l.FromIdx()]++
+		ret[l.ToIdx()]++
+	}
+	return ret
+}
+
+// density returns ratio of links number to the maximum possible one.
+func density(n, m int, directed bool) float64 {
+	if n < 2 {
+		return 0
+	}
+	max := float64(n) * float64(n-1)
+	if !directed {
+		max /= 2
+	}
+	return float64(m) / max
+}
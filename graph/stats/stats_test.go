@@ -0,0 +1,112 @@
+package stats
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/divan/graphx/generation/basic"
+	"github.com/divan/graphx/graph"
+)
+
+func TestAnalyzeGrid(t *testing.T) {
+	g := basic.NewGrid2DGenerator(3, 3).Generate()
+	r := Analyze(g, nil)
+
+	expected := &Report{
+		Nodes:            9,
+		Links:            12,
+		Density:          12.0 / 36,
+		MinDegree:        2,
+		MaxDegree:        4,
+		AvgDegree:        24.0 / 9,
+		DegreeHistogram:  []int{0, 0, 4, 4, 1},
+		Components:       1,
+		LargestComponent: 9,
+		Diameter:         4,
+		DiameterExact:    true,
+	}
+	// corners link only to side nodes: r = -1/17
+	if math.Abs(r.Assortativity+1.0/17) > 1e-12 {
+		t.Fatalf("Expected assortativity %v, but got %v", -1.0/17, r.Assortativity)
+	}
+	expected.Assortativity = r.Assortativity
+	if !reflect.DeepEqual(r, expected) {
+		t.Fatalf("Expected report %+v, but got %+v", expected, r)
+	}
+
+	if _, err := json.Marshal(r); err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+}
+
+func TestAssortativity(t *testing.T) {
+	// star is perfectly disassortative
+	g := graph.NewGraph()
+	for _, id := range []string{"c", "a", "b", "d"} {
+		g.AddNode(graph.NewBasicNode(id))
+	}
+	for _, id := range []string{"a", "b", "d"} {
+		g.AddLink("c", id)
+	}
+	if got := Assortativity(g); math.Abs(got+1) > 1e-9 {
+		t.Fatalf("Expected assortativity %v, but got %v", -1, got)
+	}
+
+	// regular graph has undefined assortativity
+	ring := basic.NewGrid2DGenerator(2, 2).Generate()
+	if got := Assortativity(ring); got != 0 {
+		t.Fatalf("Expected assortativity %v, but got %v", 0, got)
+	}
+
+	// 4x4 grid: inner nodes cluster together, r = 5/17
+	grid := basic.NewGrid2DGenerator(4, 4).Generate()
+	if got := Assortativity(grid); math.Abs(got-5.0/17) > 1e-12 {
+		t.Fatalf("Expected assortativity %v, but got %v", 5.0/17, got)
+	}
+}
+
+func TestAnalyzeParallelLinks(t *testing.T) {
+	g := graph.NewGraph()
+	for _, id := range []string{"a", "b", "c"} {
+		g.AddNode(graph.NewBasicNode(id))
+	}
+	g.AddLink("a", "b")
+	g.AddLink("a", "b")
+	g.AddLink("b", "c")
+
+	r := Analyze(g, nil)
+	// every value counts the duplicate link
+	if r.Links != 3 {
+		t.Fatalf("Expected %d links, but got %d", 3, r.Links)
+	}
+	if r.Density != 1 {
+		t.Fatalf("Expected density %v, but got %v", 1, r.Density)
+	}
+	if r.AvgDegree != 2 {
+		t.Fatalf("Expected average degree %v, but got %v", 2, r.AvgDegree)
+	}
+	if r.MinDegree != 1 || r.MaxDegree != 3 {
+		t.Fatalf("Expected degrees in [%d, %d], but got [%d, %d]", 1, 3, r.MinDegree, r.MaxDegree)
+	}
+}
+
+func TestAnalyzeSampled(t *testing.T) {
+	g := basic.NewLineGenerator(50).Generate()
+	r := Analyze(g, &Options{ExactLimit: 10, Samples: 2})
+	if r.DiameterExact {
+		t.Fatalf("Expected diameter to be estimated")
+	}
+	// double sweep finds exact diameter of the line
+	if r.Diameter != 49 {
+		t.Fatalf("Expected diameter %d, but got %d", 49, r.Diameter)
+	}
+}
+
+func TestAnalyzeEmpty(t *testing.T) {
+	r := Analyze(graph.NewGraph(), nil)
+	if r.Nodes != 0 || r.DegreeHistogram != nil || r.Density != 0 {
+		t.Fatalf("Unexpected report for empty graph: %+v", r)
+	}
+}
@@ -0,0 +1,102 @@
+package graph
+
+// Subgraph returns induced subgraph with the given nodes and all links
+// between them. Nodes keep their relative order, and links are copied.
+// It also returns mapping from the subgraph node indices to the original ones.
+func (g *TypedGraph[N]) Subgraph(ids []string) (*TypedGraph[N], []int, error) {
+	keep := make([]bool, len(g.nodes))
+	for _, id := range ids {
+		idx, err := g.NodeByID(id)
+		if err != nil {
+			return nil, nil, err
+		}
+		keep[idx] = true
+	}
+	sub, mapping := g.induced(keep)
+	return sub, mapping, nil
+}
+
+// EgoGraph returns induced subgraph with the node and all nodes within
+// the given number of hops from it, ignoring links direction. It also
+// returns mapping from the subgraph node indices to the original ones.
+func (g *TypedGraph[N]) EgoGraph(id string, hops int) (*TypedGraph[N], []int, error) {
+	start, err := g.NodeByID(id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	dist := make([]int, len(g.nodes))
+	for i := range dist {
+		dist[i] = -1
+	}
+	dist[start] = 0
+	keep := make([]bool, len(g.nodes))
+	keep[start] = true
+
+	queue := []int{start}
+	for head := 0; head < len(queue); head++ {
+		u := queue[head]
+		if dist[u] == hops {
+			continue
+		}
+		visit := func(neighbors []int) {
+			for _, v := range neighbors {
+				if dist[v] == -1 {
+					dist[v] = dist[u] + 1
+					keep[v] = true
+					queue = append(queue, v)
+				}
+			}
+		}
+		visit(g.adj[u])
+		if g.directed {
+			visit(g.inAdj[u])
+		}
+	}
+
+	sub, mapping := g.induced(keep)
+	return sub, mapping, nil
+}
+
+// FilterByGroup returns induced subgraph with nodes of the given group.
+// Nodes not implementing GroupedNode are skipped. It also returns mapping
+// from the subgraph node indices to the original ones.
+func (g *TypedGraph[N]) FilterByGroup(group int) (*TypedGraph[N], []int) {
+	keep := make([]bool, len(g.nodes))
+	for i, node := range g.nodes {
+		if gn, ok := any(node).(GroupedNode); ok && gn.Group() == group {
+			keep[i] = true
+		}
+	}
+	return g.induced(keep)
+}
+
+// induced creates subgraph with the nodes marked to keep and links between
+// them, and returns it with the mapping to the original node indices.
+func (g *TypedGraph[N]) induced(keep []bool) (*TypedGraph[N], []int) {
+	var mapping []int
+	for i, ok := range keep {
+		if ok {
+			mapping = append(mapping, i)
+		}
+	}
+
+	ret := g.empty(len(mapping), 0)
+	for _, idx := range mapping {
+		ret.AddNode(g.nodes[idx])
+	}
+	for _, link := range g.links {
+		if keep[link.fromIdx] && keep[link.toIdx] {
+			ret.AddLinks(link.Copy())
+		}
+	}
+	return ret, mapping
+}
+
+// empty creates new empty graph of the same kind.
+func (g *TypedGraph[N]) empty(m, n int) *TypedGraph[N] {
+	if g.directed {
+		return NewDirectedTypedGraphMN[N](m, n)
+	}
+	return NewTypedGraphMN[N](m, n)
+}
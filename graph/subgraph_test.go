@@ -0,0 +1,85 @@
+package graph
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSubgraph(t *testing.T) {
+	g := testGraph(5)
+	// 0-1, 1-2, 2-3, 3-4, 4-0
+	g.links[0].SetAttr("label", "x")
+
+	sub, mapping, err := g.Subgraph([]string{"4", "0", "1"})
+	if err != nil {
+		t.Fatalf("Subgraph failed: %v", err)
+	}
+	if !reflect.DeepEqual(mapping, []int{0, 1, 4}) {
+		t.Fatalf("Unexpected mapping: %v", mapping)
+	}
+	if sub.NumNodes() != 3 || sub.NumLinks() != 2 {
+		t.Fatalf("Expected %d nodes and %d links, but got %d and %d", 3, 2, sub.NumNodes(), sub.NumLinks())
+	}
+	if !sub.LinkExists("4", "0") || sub.LinkExists("1", "4") {
+		t.Fatalf("Unexpected subgraph links: %v", sub.Links())
+	}
+	checkConsistency(t, sub)
+
+	// links are copied
+	sub.Links()[0].SetAttr("label", "y")
+	if v, _ := g.links[0].Attr("label"); v != "x" {
+		t.Fatalf("Expected original link to be untouched, but got %v", v)
+	}
+
+	if _, _, err := g.Subgraph([]string{"0", "nonexistent"}); err == nil {
+		t.Fatalf("Expected error for nonexistent node")
+	}
+}
+
+func TestEgoGraph(t *testing.T) {
+	g := testGraph(8)
+	sub, mapping, err := g.EgoGraph("0", 2)
+	if err != nil {
+		t.Fatalf("EgoGraph failed: %v", err)
+	}
+	if !reflect.DeepEqual(mapping, []int{0, 1, 2, 6, 7}) {
+		t.Fatalf("Unexpected mapping: %v", mapping)
+	}
+	if sub.NumLinks() != 4 {
+		t.Fatalf("Expected %d links, but got %d", 4, sub.NumLinks())
+	}
+	checkConsistency(t, sub)
+
+	sub, _, _ = g.EgoGraph("3", 0)
+	if sub.NumNodes() != 1 || sub.NumLinks() != 0 {
+		t.Fatalf("Expected single node, but got %d nodes and %d links", sub.NumNodes(), sub.NumLinks())
+	}
+
+	// direction is ignored
+	d := NewDirectedGraph()
+	for _, id := range []string{"a", "b", "c"} {
+		d.AddNode(NewBasicNode(id))
+	}
+	d.AddLink("b", "a")
+	d.AddLink("b", "c")
+	sub, _, _ = d.EgoGraph("a", 2)
+	if !sub.Directed() || sub.NumNodes() != 3 || !sub.LinkExists("b", "c") {
+		t.Fatalf("Unexpected directed ego graph: %v", sub.Links())
+	}
+}
+
+func TestFilterByGroup(t *testing.T) {
+	g := testGraph(5)
+	for i, node := range g.Nodes() {
+		node.(*BasicNode).SetGroup(i % 2)
+	}
+	sub, mapping := g.FilterByGroup(0)
+	if !reflect.DeepEqual(mapping, []int{0, 2, 4}) {
+		t.Fatalf("Unexpected mapping: %v", mapping)
+	}
+	// only 4-0 link remains
+	if sub.NumLinks() != 1 || !sub.LinkExists("0", "4") {
+		t.Fatalf("Unexpected filtered links: %v", sub.Links())
+	}
+	checkConsistency(t, sub)
+}
@@ -0,0 +1,281 @@
+package graph
+
+import (
+	"fmt"
+	"sync"
+)
+
+// EventType represents type of the graph topology change.
+type EventType int
+
+// Predefined types of graph topology changes.
+const (
+	NodeAdded EventType = iota
+	NodeRemoved
+	LinkAdded
+	LinkRemoved
+)
+
+// String implements Stringer interface for EventType.
+func (e EventType) String() string {
+	switch e {
+	case NodeAdded:
+		return "NodeAdded"
+	case NodeRemoved:
+		return "NodeRemoved"
+	case LinkAdded:
+		return "LinkAdded"
+	case LinkRemoved:
+		return "LinkRemoved"
+	}
+	return "Unknown"
+}
+
+// Event represents single graph topology change. NodeID is set for node
+// events, From and To are set for link events. LinkAdded events also carry
+// a copy of the added link.
+type Event struct {
+	Type   EventType
+	NodeID string
+	From   string
+	To     string
+	Link   *Link
+}
+
+// SyncGraph wraps Graph and makes it safe for concurrent use. Multiple readers
+// can access graph simultaneously, while modifications are exclusive.
+// Every modification is reported to the subscribers as a sequence of events,
+// in the same order as modifications were applied.
+type SyncGraph struct {
+	mu sync.RWMutex
+	g  *Graph
+
+	notifyMu sync.Mutex // held while delivering events, keeps them ordered
+	subs     []*subscription
+}
+
+// subscription represents single subscriber of graph events.
+type subscription struct {
+	ch   chan Event
+	done chan struct{}
+	once sync.Once
+}
+
+// NewSyncGraph creates new concurrency-safe wrapper for g. Graph should not be
+// accessed directly after that.
+func NewSyncGraph(g *Graph) *SyncGraph {
+	return &SyncGraph{
+		g: g,
+	}
+}
+
+// Subscribe returns channel with graph change events and a function to cancel
+// subscription. Events are delivered synchronously, so modifications block until
+// all subscribers received events (buffer size allows to smooth it). Reading events
+// and modifying the graph from the same goroutine may lead to deadlock.
+//
+// Subscribing from within Read guarantees that events describe exactly the
+// changes made after fn has seen the graph.
+func (s *SyncGraph) Subscribe(buffer int) (<-chan Event, func()) {
+	sub := &subscription{
+		ch:   make(chan Event, buffer),
+		done: make(chan struct{}),
+	}
+
+	s.notifyMu.Lock()
+	s.subs = append(s.subs, sub)
+	s.notifyMu.Unlock()
+
+	cancel := func() {
+		sub.once.Do(func() {
+			close(sub.done) // unblock pending delivery, if any
+
+			s.notifyMu.Lock()
+			defer s.notifyMu.Unlock()
+			for i := range s.subs {
+				if s.subs[i] == sub {
+					s.subs = append(s.subs[:i], s.subs[i+1:]...)
+					break
+				}
+			}
+			close(sub.ch)
+		})
+	}
+	return sub.ch, cancel
+}
+
+// Read runs fn with read access to the underlying graph. Graph must not
+// be modified or retained by fn.
+func (s *SyncGraph) Read(fn func(g *Graph)) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	fn(s.g)
+}
+
+// Freeze creates read-only CSR snapshot of the graph.
+func (s *SyncGraph) Freeze() *CSR {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.g.Freeze()
+}
+
+// NumNodes returns total number of graph nodes.
+func (s *SyncGraph) NumNodes() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.g.NumNodes()
+}
+
+// NumLinks returns total number of graph links.
+func (s *SyncGraph) NumLinks() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.g.NumLinks()
+}
+
+// Node returns Node by its string ID.
+func (s *SyncGraph) Node(id string) (Node, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.g.Node(id)
+}
+
+// LinkExists returns true if there is a link between source and target.
+func (s *SyncGraph) LinkExists(from, to string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.g.LinkExists(from, to)
+}
+
+// Neighbors returns IDs of the nodes linked with the node with given ID.
+func (s *SyncGraph) Neighbors(id string) ([]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.g.Neighbors(id)
+}
+
+// AddNode adds new node to graph. Unlike Graph.AddNode, it returns error if
+// node with the same ID already exists, which is checked atomically with
+// adding the node.
+func (s *SyncGraph) AddNode(node Node) error {
+	s.mu.Lock()
+	if _, err := s.g.NodeByID(node.ID()); err == nil {
+		s.mu.Unlock()
+		return fmt.Errorf("node %s already exists", node.ID())
+	}
+	s.g.AddNode(node)
+
+	s.notifyMu.Lock()
+	defer s.notifyMu.Unlock()
+	s.mu.Unlock()
+	s.notify(Event{Type: NodeAdded, NodeID: node.ID()})
+	return nil
+}
+
+// AddLink adds new link to the graph.
+func (s *SyncGraph) AddLink(from, to string) error {
+	return s.AddLinks(NewLink(from, to))
+}
+
+// AddWeightedLink adds new link with the given weight to the graph.
+func (s *SyncGraph) AddWeightedLink(from, to string, weight float64) error {
+	return s.AddLinks(NewWeightedLink(from, to, weight))
+}
+
+// AddLinks adds prepared links to the graph. Links are added in order, and
+// it stops on the first invalid link.
+func (s *SyncGraph) AddLinks(links ...*Link) error {
+	s.mu.Lock()
+	n := s.g.NumLinks()
+	err := s.g.AddLinks(links...)
+
+	added := s.g.Links()[n:]
+	events := make([]Event, len(added))
+	for i, link := range added {
+		events[i] = Event{Type: LinkAdded, From: link.From(), To: link.To(), Link: link.Copy()}
+	}
+
+	s.notifyMu.Lock()
+	defer s.notifyMu.Unlock()
+	s.mu.Unlock()
+	s.notify(events...)
+	return err
+}
+
+// RemoveLink removes link between source and target from the graph.
+func (s *SyncGraph) RemoveLink(from, to string) error {
+	s.mu.Lock()
+	idx, err := s.g.LinkIndex(from, to)
+	if err != nil {
+		s.mu.Unlock()
+		return err
+	}
+	link := s.g.Links()[idx]
+	err = s.g.RemoveLink(from, to)
+	if err != nil {
+		s.mu.Unlock()
+		return err
+	}
+
+	s.notifyMu.Lock()
+	defer s.notifyMu.Unlock()
+	s.mu.Unlock()
+	s.notify(Event{Type: LinkRemoved, From: link.From(), To: link.To()})
+	return nil
+}
+
+// RemoveNode removes node with the given ID from the graph, along with
+// all links connected to it. LinkRemoved events are sent before NodeRemoved.
+func (s *SyncGraph) RemoveNode(id string) error {
+	return s.RemoveNodes(id)
+}
+
+// RemoveNodes removes nodes with given IDs from the graph, along with all
+// links connected to them.
+func (s *SyncGraph) RemoveNodes(ids ...string) error {
+	s.mu.Lock()
+	removed := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		removed[id] = true
+	}
+	var events []Event
+	for _, link := range s.g.Links() {
+		if removed[link.From()] || removed[link.To()] {
+			events = append(events, Event{Type: LinkRemoved, From: link.From(), To: link.To()})
+		}
+	}
+
+	err := s.g.RemoveNodes(ids...)
+	if err != nil {
+		s.mu.Unlock()
+		return err
+	}
+	for _, id := range ids {
+		if !removed[id] {
+			continue // duplicate ID
+		}
+		delete(removed, id)
+		events = append(events, Event{Type: NodeRemoved, NodeID: id})
+	}
+
+	s.notifyMu.Lock()
+	defer s.notifyMu.Unlock()
+	s.mu.Unlock()
+	s.notify(events...)
+	return nil
+}
+
+// notify delivers events to all subscribers. It must be called with notifyMu
+// held. Modifications take notifyMu before releasing the write lock, so events
+// are delivered in order of modifications, while readers are not blocked by
+// slow subscribers.
+func (s *SyncGraph) notify(events ...Event) {
+	for _, e := range events {
+		for _, sub := range s.subs {
+			select {
+			case sub.ch <- e:
+			case <-sub.done:
+			}
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package graph
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestSyncGraphEvents(t *testing.T) {
+	s := NewSyncGraph(NewGraph())
+	events, cancel := s.Subscribe(10)
+
+	s.AddNode(NewBasicNode("a"))
+	s.AddNode(NewBasicNode("b"))
+	if err := s.AddNode(NewBasicNode("a")); err == nil {
+		t.Fatalf("Expected error for existing node")
+	}
+	s.AddLink("a", "b")
+	if err := s.AddLink("a", "nonexistent"); err == nil {
+		t.Fatalf("Expected error for invalid link")
+	}
+	s.RemoveNode("b")
+	cancel()
+
+	expected := []Event{
+		{Type: NodeAdded, NodeID: "a"},
+		{Type: NodeAdded, NodeID: "b"},
+		{Type: LinkAdded, From: "a", To: "b"},
+		{Type: LinkRemoved, From: "a", To: "b"},
+		{Type: NodeRemoved, NodeID: "b"},
+	}
+	var got []Event
+	for e := range events {
+		got = append(got, e)
+	}
+	if len(got) != len(expected) {
+		t.Fatalf("Expected %d events, but got %d: %v", len(expected), len(got), got)
+	}
+	for i := range expected {
+		if got[i].Type == LinkAdded {
+			if l := got[i].Link; l == nil || l.From() != "a" || l.To() != "b" {
+				t.Fatalf("Expected event to carry added link, but got %v", l)
+			}
+			got[i].Link = nil
+		}
+		if got[i] != expected[i] {
+			t.Fatalf("Expected event %v, but got %v", expected[i], got[i])
+		}
+	}
+
+	// no subscribers anymore, shouldn't block
+	s.AddNode(NewBasicNode("c"))
+}
+
+func TestSyncGraphConcurrent(t *testing.T) {
+	s := NewSyncGraph(NewGraph())
+	events, cancel := s.Subscribe(0)
+
+	var received int
+	done := make(chan struct{})
+	go func() {
+		for range events {
+			received++
+		}
+		close(done)
+	}()
+
+	var (
+		wg    sync.WaitGroup
+		dupMu sync.Mutex
+		dups  int
+	)
+	for w := 0; w < 4; w++ {
+		wg.Add(2)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				s.AddNode(NewBasicNode(fmt.Sprintf("%d-%d", w, i)))
+				// every worker tries to add the same shared node
+				if err := s.AddNode(NewBasicNode(fmt.Sprintf("shared-%d", i))); err != nil {
+					dupMu.Lock()
+					dups++
+					dupMu.Unlock()
+				}
+			}
+		}(w)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				s.Read(func(g *Graph) {
+					for _, link := range g.Links() {
+						_ = link.From()
+					}
+				})
+				s.Freeze()
+			}
+		}()
+	}
+	wg.Wait()
+	cancel()
+	<-done
+
+	if s.NumNodes() != 500 || received != 500 {
+		t.Fatalf("Expected %d nodes and events, but got %d and %d", 500, s.NumNodes(), received)
+	}
+	if dups != 300 {
+		t.Fatalf("Expected %d duplicate nodes to be rejected, but got %d", 300, dups)
+	}
+}
@@ -0,0 +1,189 @@
+// Package traverse implements breadth-first and depth-first graph traversal.
+//
+// Example:
+//
+//	res, err := traverse.BFS(g, "192.168.1.2", &traverse.Options{
+//	    MaxDepth: 2,
+//	    Visit: func(id string, depth int) bool {
+//	        fmt.Println(id, depth)
+//	        return true
+//	    },
+//	})
+package traverse
+
+import "github.com/divan/graphx/graph"
+
+// Visitor is called for each visited node with its ID and depth, and
+// returns false to stop traversal.
+type Visitor func(id string, depth int) bool
+
+// Options specifies traversal parameters. Nil options are equal to
+// zero value.
+type Options struct {
+	MaxDepth        int     // maximum depth to traverse, 0 means no limit
+	IgnoreDirection bool    // follow links in both directions for directed graphs
+	Visit           Visitor // optional visitor callback
+}
+
+// Result holds traversal results.
+type Result struct {
+	Order  []string          // IDs of visited nodes in order of visiting
+	Depth  map[string]int    // depth of each visited node
+	Parent map[string]string // parent of each visited node, except the start one
+}
+
+// newResult creates empty result.
+func newResult() *Result {
+	return &Result{
+		Depth:  make(map[string]int),
+		Parent: make(map[string]string),
+	}
+}
+
+// Visited returns true if node with given ID was visited.
+func (r *Result) Visited(id string) bool {
+	_, ok := r.Depth[id]
+	return ok
+}
+
+// PathTo returns path from the start node to the visited node with given ID,
+// following parents. It returns nil if node wasn't visited.
+func (r *Result) PathTo(id string) []string {
+	if !r.Visited(id) {
+		return nil
+	}
+
+	path := make([]string, r.Depth[id]+1)
+	for i := len(path) - 1; i >= 0; i-- {
+		path[i] = id
+		id = r.Parent[id]
+	}
+	return path
+}
+
+// BFS traverses graph in breadth-first order starting from the node with
+// given ID. Depth of each node is the hop distance from the start node.
+func BFS(g *graph.Graph, start string, opts *Options) (*Result, error) {
+	startIdx, err := g.NodeByID(start)
+	if err != nil {
+		return nil, err
+	}
+	if opts == nil {
+		opts = &Options{}
+	}
+
+	nodes := g.Nodes()
+	res := newResult()
+	depth := make([]int, g.NumNodes())
+	for i := range depth {
+		depth[i] = -1
+	}
+
+	depth[startIdx] = 0
+	queue := []int{startIdx}
+	for len(queue) > 0 {
+		idx := queue[0]
+		queue = queue[1:]
+
+		id := nodes[idx].ID()
+		res.Order = append(res.Order, id)
+		res.Depth[id] = depth[idx]
+		if opts.Visit != nil && !opts.Visit(id, depth[idx]) {
+			break
+		}
+
+		if opts.MaxDepth > 0 && depth[idx] >= opts.MaxDepth {
+			continue
+		}
+		forEachNeighbor(g, idx, opts.IgnoreDirection, func(n int) {
+			if depth[n] != -1 {
+				return
+			}
+			depth[n] = depth[idx] + 1
+			res.Parent[nodes[n].ID()] = id
+			queue = append(queue, n)
+		})
+	}
+
+	return res, nil
+}
+
+// DFS traverses graph in depth-first order starting from the node with
+// given ID. Depth of each node is its depth in the DFS tree, which is not
+// necessarily the shortest distance.
+func DFS(g *graph.Graph, start string, opts *Options) (*Result, error) {
+	startIdx, err := g.NodeByID(start)
+	if err != nil {
+		return nil, err
+	}
+	if opts == nil {
+		opts = &Options{}
+	}
+
+	nodes := g.Nodes()
+	res := newResult()
+	visited := make([]bool, g.NumNodes())
+
+	type item struct {
+		idx, parent, depth int
+	}
+	stack := []item{{idx: startIdx, parent: -1}}
+	var neighbors []int
+	for len(stack) > 0 {
+		it := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if visited[it.idx] {
+			continue
+		}
+		visited[it.idx] = true
+
+		id := nodes[it.idx].ID()
+		res.Order = append(res.Order, id)
+		res.Depth[id] = it.depth
+		if it.parent != -1 {
+			res.Parent[id] = nodes[it.parent].ID()
+		}
+		if opts.Visit != nil && !opts.Visit(id, it.depth) {
+			break
+		}
+
+		if opts.MaxDepth > 0 && it.depth >= opts.MaxDepth {
+			continue
+		}
+		// push in reverse order, so neighbors are visited in order of links addition
+		neighbors = neighbors[:0]
+		forEachNeighbor(g, it.idx, opts.IgnoreDirection, func(n int) {
+			if !visited[n] {
+				neighbors = append(neighbors, n)
+			}
+		})
+		for i := len(neighbors) - 1; i >= 0; i-- {
+			stack = append(stack, item{idx: neighbors[i], parent: it.idx, depth: it.depth + 1})
+		}
+	}
+
+	return res, nil
+}
+
+// Distances returns hop distances from the node with given ID to all
+// reachable nodes, keyed by node ID.
+func Distances(g *graph.Graph, start string) (map[string]int, error) {
+	res, err := BFS(g, start, nil)
+	if err != nil {
+		return nil, err
+	}
+	return res.Depth, nil
+}
+
+// forEachNeighbor calls fn for each neighbor of the node with index idx.
+// If both is true, incoming links of directed graph are considered as well.
+func forEachNeighbor(g *graph.Graph, idx int, both bool, fn func(n int)) {
+	for _, n := range g.NeighborIndices(idx) {
+		fn(n)
+	}
+	if both && g.Directed() {
+		for _, n := range g.InNeighborIndices(idx) {
+			fn(n)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package traverse
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/divan/graphx/generation/basic"
+	"github.com/divan/graphx/graph"
+)
+
+func TestBFS(t *testing.T) {
+	g := basic.NewGrid2DGenerator(3, 3).Generate()
+	// 0 1 2
+	// 3 4 5
+	// 6 7 8
+	res, err := BFS(g, "0", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Order) != 9 {
+		t.Fatalf("Expected to visit %d nodes, but visited %d", 9, len(res.Order))
+	}
+	if res.Depth["8"] != 4 || res.Depth["4"] != 2 {
+		t.Fatalf("Unexpected hop distances: %v", res.Depth)
+	}
+	if path := res.PathTo("8"); len(path) != 5 || path[0] != "0" || path[4] != "8" {
+		t.Fatalf("Unexpected path: %v", path)
+	}
+
+	res, err = BFS(g, "4", &Options{MaxDepth: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Order) != 5 || res.Visited("0") {
+		t.Fatalf("Expected depth limit to be respected, but visited %v", res.Order)
+	}
+
+	_, err = BFS(g, "nonexistent", nil)
+	if err == nil {
+		t.Fatalf("Expected error for nonexistent start node")
+	}
+}
+
+func TestBFSStop(t *testing.T) {
+	g := basic.NewLineGenerator(10).Generate()
+	var visited []string
+	_, err := BFS(g, "0", &Options{
+		Visit: func(id string, depth int) bool {
+			visited = append(visited, id)
+			return id != "3"
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"0", "1", "2", "3"}
+	if !reflect.DeepEqual(visited, expected) {
+		t.Fatalf("Expected to visit %v, but visited %v", expected, visited)
+	}
+}
+
+func TestDFS(t *testing.T) {
+	g := basic.NewGrid2DGenerator(3, 3).Generate()
+	res, err := DFS(g, "0", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// neighbors are visited in order of links addition
+	expected := []string{"0", "1", "2", "5", "4", "3", "6", "7", "8"}
+	if !reflect.DeepEqual(res.Order, expected) {
+		t.Fatalf("Expected order %v, but got %v", expected, res.Order)
+	}
+	if res.Depth["8"] != 8 {
+		t.Fatalf("Expected DFS tree depth %d, but got %d", 8, res.Depth["8"])
+	}
+}
+
+func TestDirected(t *testing.T) {
+	g := graph.NewDirectedGraph()
+	for _, id := range []string{"a", "b", "c"} {
+		g.AddNode(graph.NewBasicNode(id))
+	}
+	g.AddLink("a", "b")
+	g.AddLink("c", "b")
+
+	dist, err := Distances(g, "a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dist) != 2 {
+		t.Fatalf("Expected only 'a' and 'b' to be reachable, but got %v", dist)
+	}
+
+	res, err := BFS(g, "a", &Options{IgnoreDirection: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Depth["c"] != 2 {
+		t.Fatalf("Expected 'c' to be reachable ignoring direction, but got %v", res.Depth)
+	}
+}
@@ -0,0 +1,74 @@
+package walk
+
+// HittingTime estimates expected number of steps for the walk started at
+// the from node to reach the to node for the first time, averaged over
+// Options.Walks sampled walks. Walks not reaching the target within
+// Options.MaxSteps are counted as MaxSteps, so the estimate is a lower bound
+// for poorly connected nodes. It returns ErrNotReached if no walk reaches
+// the target.
+//
+// Note, that restarts and node2vec bias affect the walk as well, so use
+// uniform walker for classic random walk hitting time.
+func (w *Walker) HittingTime(from, to string) (float64, error) {
+	s, err := w.index(from)
+	if err != nil {
+		return 0, err
+	}
+	t, err := w.index(to)
+	if err != nil {
+		return 0, err
+	}
+	if s == t {
+		return 0, nil
+	}
+
+	var total, reached int
+	for i := 0; i < w.opts.Walks; i++ {
+		steps := w.hit(s, t)
+		if steps < w.opts.MaxSteps {
+			reached++
+		}
+		total += steps
+	}
+	if reached == 0 {
+		return 0, ErrNotReached
+	}
+	return float64(total) / float64(w.opts.Walks), nil
+}
+
+// CommuteTime estimates expected number of steps for the walk to go from one
+// node to another and back, which is the sum of hitting times in both directions.
+func (w *Walker) CommuteTime(a, b string) (float64, error) {
+	there, err := w.HittingTime(a, b)
+	if err != nil {
+		return 0, err
+	}
+	back, err := w.HittingTime(b, a)
+	if err != nil {
+		return 0, err
+	}
+	return there + back, nil
+}
+
+// hit returns number of steps for the walk from s to reach t, limited
+// by MaxSteps.
+func (w *Walker) hit(s, t int) int {
+	prev, cur := -1, s
+	for steps := 1; steps <= w.opts.MaxSteps; steps++ {
+		var next int
+		if w.restart > 0 && w.opts.Rand.Float64() < w.restart {
+			next, prev = s, -1
+		} else {
+			next = w.step(prev, cur)
+			if next == -1 {
+				return w.opts.MaxSteps
+			}
+			prev = cur
+		}
+		if next == t {
+			return steps
+		}
+		cur = next
+	}
+	return w.opts.MaxSteps
+}
@@ -0,0 +1,293 @@
+// Package walk implements random walks over graphs: uniform, node2vec-style
+// biased and restart walks, walk corpus generation for embeddings, and
+// estimation of hitting and commute times.
+//
+// Walks follow outgoing links for directed graphs, and next node is chosen
+// with probability proportional to the link weight, where weights of duplicate
+// links are summed up. Link weights must be non-negative. Walk stops early at
+// nodes without outgoing links or with zero total weight of them.
+package walk
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"sort"
+	"strings"
+
+	"github.com/divan/graphx/graph"
+)
+
+// Default values for Options.
+const (
+	DefaultLength   = 80
+	DefaultWalks    = 10
+	DefaultMaxSteps = 100000
+)
+
+// ErrNotReached is returned when no walk reaches the target within the
+// maximum number of steps.
+var ErrNotReached = errors.New("target not reached")
+
+// Options specifies parameters for random walks. Nil options or zero values
+// mean defaults.
+type Options struct {
+	Length int // number of nodes in each walk, including the start node
+	// Walks is the number of walks per node for corpus, and the number
+	// of sampled walks for hitting time estimation.
+	Walks int
+	// MaxSteps limits walk length for hitting time estimation.
+	MaxSteps int
+	// Rand is a source of randomness. If nil, deterministic source is used.
+	Rand *rand.Rand
+}
+
+// withDefaults returns copy of options with defaults filled.
+func (o *Options) withDefaults() Options {
+	var ret Options
+	if o != nil {
+		ret = *o
+	}
+	if ret.Length <= 0 {
+		ret.Length = DefaultLength
+	}
+	if ret.Walks <= 0 {
+		ret.Walks = DefaultWalks
+	}
+	if ret.MaxSteps <= 0 {
+		ret.MaxSteps = DefaultMaxSteps
+	}
+	if ret.Rand == nil {
+		ret.Rand = rand.New(rand.NewSource(1))
+	}
+	return ret
+}
+
+// Walker generates random walks over the graph snapshot. Walker isn't safe
+// for concurrent use, as it shares the source of randomness.
+type Walker struct {
+	c       *graph.CSR
+	weights [][]float64 // summed weights of links to c.Neighbors
+	opts    Options
+	byID    map[string]int
+
+	// node2vec parameters
+	p, q   float64
+	sorted [][]int32 // sorted neighbors for adjacency checks
+
+	restart float64 // probability to jump back to the start node
+
+	probs []float64 // buffer for transition probabilities
+}
+
+// NewUniform creates walker for uniform random walks. It returns error if
+// any link has negative weight.
+func NewUniform(g *graph.Graph, opts *Options) (*Walker, error) {
+	c := g.Freeze()
+	weights, err := summedWeights(g, c)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]int, c.NumNodes())
+	for i, id := range c.IDs() {
+		byID[id] = i
+	}
+	return &Walker{
+		c:       c,
+		weights: weights,
+		opts:    opts.withDefaults(),
+		byID:    byID,
+		p:       1,
+		q:       1,
+	}, nil
+}
+
+// summedWeights returns weights of links to each node's neighbors in the
+// snapshot, summed up over duplicate links.
+func summedWeights(g *graph.Graph, c *graph.CSR) ([][]float64, error) {
+	pos := make(map[[2]int]int)
+	ret := make([][]float64, c.NumNodes())
+	for u := range ret {
+		neighbors := c.Neighbors(u)
+		ret[u] = make([]float64, len(neighbors))
+		for i, v := range neighbors {
+			pos[[2]int{u, int(v)}] = i
+		}
+	}
+	add := func(u, v int, w float64) {
+		ret[u][pos[[2]int{u, v}]] += w
+	}
+	for _, l := range g.Links() {
+		if !(l.Weight() >= 0) {
+			return nil, fmt.Errorf("link weight must be non-negative, but got %v for %s -> %s", l.Weight(), l.From(), l.To())
+		}
+		add(l.FromIdx(), l.ToIdx(), l.Weight())
+		if !g.Directed() && l.FromIdx() != l.ToIdx() {
+			add(l.ToIdx(), l.FromIdx(), l.Weight())
+		}
+	}
+	return ret, nil
+}
+
+// NewNode2Vec creates walker for biased second order random walks, as in
+// node2vec. Return parameter p controls likelihood of returning to the previous
+// node, and in-out parameter q controls whether walk stays close to the previous
+// node (q > 1, BFS-like) or moves away from it (q < 1, DFS-like). Both
+// parameters must be positive.
+func NewNode2Vec(g *graph.Graph, p, q float64, opts *Options) (*Walker, error) {
+	if !(p > 0 && q > 0) {
+		return nil, fmt.Errorf("node2vec parameters must be positive, but got p=%v, q=%v", p, q)
+	}
+	w, err := NewUniform(g, opts)
+	if err != nil {
+		return nil, err
+	}
+	w.p, w.q = p, q
+	if p != 1 || q != 1 {
+		w.sorted = make([][]int32, w.c.NumNodes())
+		for i := range w.sorted {
+			nb := append([]int32(nil), w.c.Neighbors(i)...)
+			sort.Slice(nb, func(a, b int) bool { return nb[a] < nb[b] })
+			w.sorted[i] = nb
+		}
+	}
+	return w, nil
+}
+
+// NewRestart creates walker for random walks with restarts, where on each
+// step walk jumps back to the start node with the given probability, which
+// must be in [0, 1] range.
+func NewRestart(g *graph.Graph, restart float64, opts *Options) (*Walker, error) {
+	if !(restart >= 0 && restart <= 1) {
+		return nil, fmt.Errorf("restart probability must be in [0, 1], but got %v", restart)
+	}
+	w, err := NewUniform(g, opts)
+	if err != nil {
+		return nil, err
+	}
+	w.restart = restart
+	return w, nil
+}
+
+// Walk returns random walk from the given node as a list of node IDs.
+func (w *Walker) Walk(start string) ([]string, error) {
+	idx, err := w.index(start)
+	if err != nil {
+		return nil, err
+	}
+	return w.ids(w.walk(idx, w.opts.Length)), nil
+}
+
+// Corpus returns walks corpus: Options.Walks walks from every node, where on
+// each round nodes are visited in random order.
+func (w *Walker) Corpus() [][]string {
+	n := w.c.NumNodes()
+	ret := make([][]string, 0, n*w.opts.Walks)
+	for i := 0; i < w.opts.Walks; i++ {
+		for _, start := range w.opts.Rand.Perm(n) {
+			ret = append(ret, w.ids(w.walk(start, w.opts.Length)))
+		}
+	}
+	return ret
+}
+
+// WriteCorpus writes walks as text, one walk per line with space separated
+// node IDs, which is the input format of word2vec-like tools.
+func WriteCorpus(out io.Writer, walks [][]string) error {
+	bw := bufio.NewWriter(out)
+	for _, walk := range walks {
+		if _, err := bw.WriteString(strings.Join(walk, " ")); err != nil {
+			return err
+		}
+		if err := bw.WriteByte('\n'); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+// walk generates walk of at most length nodes.
+func (w *Walker) walk(start, length int) []int {
+	walk := make([]int, 1, length)
+	walk[0] = start
+	prev := -1
+	for len(walk) < length {
+		cur := walk[len(walk)-1]
+		if w.restart > 0 && w.opts.Rand.Float64() < w.restart {
+			walk = append(walk, start)
+			prev = -1
+			continue
+		}
+		next := w.step(prev, cur)
+		if next == -1 {
+			break
+		}
+		walk = append(walk, next)
+		prev = cur
+	}
+	return walk
+}
+
+// step returns next node of the walk from cur, where prev is the previous
+// node or -1. It returns -1 if there are no outgoing links or their total
+// weight is zero.
+func (w *Walker) step(prev, cur int) int {
+	neighbors, weights := w.c.Neighbors(cur), w.weights[cur]
+	if len(neighbors) == 0 {
+		return -1
+	}
+
+	w.probs = w.probs[:0]
+	var sum float64
+	for i, v := range neighbors {
+		p := weights[i]
+		if prev != -1 && w.sorted != nil {
+			switch {
+			case int(v) == prev:
+				p /= w.p
+			case !w.linked(prev, v):
+				p /= w.q
+			}
+		}
+		sum += p
+		w.probs = append(w.probs, sum)
+	}
+	if sum == 0 {
+		return -1
+	}
+
+	x := w.opts.Rand.Float64() * sum
+	i := sort.Search(len(w.probs), func(i int) bool { return w.probs[i] > x })
+	if i == len(neighbors) {
+		// x rounded up to sum, pick the last link with non-zero weight
+		i = sort.Search(len(w.probs), func(i int) bool { return w.probs[i] >= sum })
+	}
+	return int(neighbors[i])
+}
+
+// linked checks if there is a link from u to v.
+func (w *Walker) linked(u int, v int32) bool {
+	nb := w.sorted[u]
+	i := sort.Search(len(nb), func(i int) bool { return nb[i] >= v })
+	return i < len(nb) && nb[i] == v
+}
+
+// index returns node index by its ID.
+func (w *Walker) index(id string) (int, error) {
+	idx, ok := w.byID[id]
+	if !ok {
+		return 0, fmt.Errorf("node %s not found", id)
+	}
+	return idx, nil
+}
+
+// ids converts node indices into IDs.
+func (w *Walker) ids(walk []int) []string {
+	ret := make([]string, len(walk))
+	for i, idx := range walk {
+		ret[i] = w.c.ID(idx)
+	}
+	return ret
+}
@@ -0,0 +1,307 @@
+package walk
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/divan/graphx/generation/basic"
+	"github.com/divan/graphx/graph"
+)
+
+// complete creates complete graph with n nodes.
+func complete(n int) *graph.Graph {
+	g := graph.NewGraph()
+	for i := 0; i < n; i++ {
+		g.AddNode(graph.NewBasicNode(fmt.Sprint(i)))
+	}
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			g.AddLink(fmt.Sprint(i), fmt.Sprint(j))
+		}
+	}
+	return g
+}
+
+// uniform creates uniform walker, failing the test on error.
+func uniform(t *testing.T, g *graph.Graph, opts *Options) *Walker {
+	t.Helper()
+	w, err := NewUniform(g, opts)
+	if err != nil {
+		t.Fatalf("NewUniform failed: %v", err)
+	}
+	return w
+}
+
+// checkWalk checks that consecutive walk nodes are linked.
+func checkWalk(t *testing.T, g *graph.Graph, walk []string) {
+	t.Helper()
+	for i := 1; i < len(walk); i++ {
+		if !g.LinkExists(walk[i-1], walk[i]) {
+			t.Fatalf("Walk %v is broken: no link %s -> %s", walk, walk[i-1], walk[i])
+		}
+	}
+}
+
+func TestUniform(t *testing.T) {
+	g := basic.NewGrid2DGenerator(5, 5).Generate()
+	w := uniform(t, g, &Options{Length: 20})
+	walk, err := w.Walk("0")
+	if err != nil {
+		t.Fatalf("Walk failed: %v", err)
+	}
+	if len(walk) != 20 || walk[0] != "0" {
+		t.Fatalf("Unexpected walk: %v", walk)
+	}
+	checkWalk(t, g, walk)
+
+	if _, err := w.Walk("nonexistent"); err == nil {
+		t.Fatalf("Expected error for unknown node")
+	}
+
+	// the same seed gives the same walk
+	a, _ := uniform(t, g, &Options{Rand: rand.New(rand.NewSource(7))}).Walk("3")
+	b, _ := uniform(t, g, &Options{Rand: rand.New(rand.NewSource(7))}).Walk("3")
+	if strings.Join(a, " ") != strings.Join(b, " ") {
+		t.Fatalf("Expected seeded walks to be equal")
+	}
+}
+
+func TestWeighted(t *testing.T) {
+	g := graph.NewDirectedGraph()
+	for _, id := range []string{"a", "b", "c"} {
+		g.AddNode(graph.NewBasicNode(id))
+	}
+	g.AddWeightedLink("a", "b", 9)
+	g.AddWeightedLink("a", "c", 1)
+
+	w := uniform(t, g, &Options{Length: 2})
+	var count int
+	for i := 0; i < 1000; i++ {
+		walk, _ := w.Walk("a")
+		if walk[1] == "b" {
+			count++
+		}
+	}
+	if count < 850 || count > 950 {
+		t.Fatalf("Expected about 900 walks to go to 'b', but got %d", count)
+	}
+
+	// walk stops at dead end
+	walk, _ := w.Walk("b")
+	if len(walk) != 1 {
+		t.Fatalf("Expected walk to stop at dead end, but got %v", walk)
+	}
+
+	// weights of duplicate links sum up
+	g.AddWeightedLink("a", "c", 8)
+	w = uniform(t, g, &Options{Length: 2})
+	count = 0
+	for i := 0; i < 1000; i++ {
+		walk, _ := w.Walk("a")
+		if walk[1] == "b" {
+			count++
+		}
+	}
+	if count < 400 || count > 600 {
+		t.Fatalf("Expected about 500 walks to go to 'b', but got %d", count)
+	}
+}
+
+func TestZeroWeights(t *testing.T) {
+	g := graph.NewDirectedGraph()
+	for _, id := range []string{"a", "b", "c", "d"} {
+		g.AddNode(graph.NewBasicNode(id))
+	}
+	g.AddWeightedLink("a", "b", 1)
+	g.AddWeightedLink("a", "c", 0)
+	g.AddWeightedLink("b", "c", 0)
+	g.AddWeightedLink("b", "d", 0)
+
+	// zero weight links are never taken, and node with zero total weight
+	// is a dead end
+	w := uniform(t, g, &Options{Length: 5})
+	for i := 0; i < 100; i++ {
+		walk, _ := w.Walk("a")
+		if strings.Join(walk, " ") != "a b" {
+			t.Fatalf("Expected walk %q, but got %v", "a b", walk)
+		}
+	}
+
+	g.AddWeightedLink("c", "d", -1)
+	if _, err := NewUniform(g, nil); err == nil {
+		t.Fatalf("Expected error for negative link weight")
+	}
+	if _, err := NewNode2Vec(g, 1, 1, nil); err == nil {
+		t.Fatalf("Expected error for negative link weight")
+	}
+	if _, err := NewRestart(g, 0.5, nil); err == nil {
+		t.Fatalf("Expected error for negative link weight")
+	}
+}
+
+func TestNode2Vec(t *testing.T) {
+	g := basic.NewGrid2DGenerator(5, 5).Generate()
+
+	// low p makes walk return to the previous node
+	w, err := NewNode2Vec(g, 1e-9, 1, &Options{Length: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	walk, _ := w.Walk("12")
+	checkWalk(t, g, walk)
+	for i := 2; i < len(walk); i++ {
+		if walk[i] != walk[i-2] {
+			t.Fatalf("Expected walk to go back and forth, but got %v", walk)
+		}
+	}
+
+	// low q on complete graph never returns to the previous node, as all
+	// other nodes are linked to it
+	k := complete(5)
+	w, err = NewNode2Vec(k, 1e9, 1e-9, &Options{Length: 50})
+	if err != nil {
+		t.Fatal(err)
+	}
+	walk, _ = w.Walk("0")
+	for i := 2; i < len(walk); i++ {
+		if walk[i] == walk[i-2] {
+			t.Fatalf("Expected walk to never return, but got %v", walk)
+		}
+	}
+
+	for _, pq := range [][2]float64{{-1, 1}, {1, 0}} {
+		if _, err := NewNode2Vec(g, pq[0], pq[1], nil); err == nil {
+			t.Fatalf("Expected error for p=%v, q=%v", pq[0], pq[1])
+		}
+	}
+}
+
+func TestRestart(t *testing.T) {
+	g := basic.NewLineGenerator(10).Generate()
+	w, err := NewRestart(g, 1, &Options{Length: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	walk, _ := w.Walk("4")
+	if strings.Join(walk, " ") != "4 4 4 4 4" {
+		t.Fatalf("Expected walk to always restart, but got %v", walk)
+	}
+
+	// walk stays near the start node
+	w, err = NewRestart(g, 0.5, &Options{Length: 1000})
+	if err != nil {
+		t.Fatal(err)
+	}
+	walk, _ = w.Walk("0")
+	var count int
+	for _, id := range walk {
+		if id == "0" {
+			count++
+		}
+	}
+	if count < 500 {
+		t.Fatalf("Expected start node to be visited at least %d times, but got %d", 500, count)
+	}
+
+	for _, restart := range []float64{-0.1, 1.5} {
+		if _, err := NewRestart(g, restart, nil); err == nil {
+			t.Fatalf("Expected error for restart probability %v", restart)
+		}
+	}
+}
+
+func TestCorpus(t *testing.T) {
+	g := basic.NewGrid2DGenerator(3, 3).Generate()
+	w := uniform(t, g, &Options{Length: 4, Walks: 2})
+	walks := w.Corpus()
+	if len(walks) != 18 {
+		t.Fatalf("Expected %d walks, but got %d", 18, len(walks))
+	}
+	starts := make(map[string]int)
+	for _, walk := range walks {
+		checkWalk(t, g, walk)
+		starts[walk[0]]++
+	}
+	for id, n := range starts {
+		if n != 2 {
+			t.Fatalf("Expected %d walks from %s, but got %d", 2, id, n)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := WriteCorpus(&buf, walks[:2]); err != nil {
+		t.Fatalf("WriteCorpus failed: %v", err)
+	}
+	expected := strings.Join(walks[0], " ") + "\n" + strings.Join(walks[1], " ") + "\n"
+	if buf.String() != expected {
+		t.Fatalf("Expected corpus %q, but got %q", expected, buf.String())
+	}
+}
+
+func TestHittingTime(t *testing.T) {
+	// on complete graph with n nodes, hitting time is n-1
+	w := uniform(t, complete(10), &Options{Walks: 5000})
+	h, err := w.HittingTime("0", "5")
+	if err != nil {
+		t.Fatalf("HittingTime failed: %v", err)
+	}
+	if math.Abs(h-9) > 0.5 {
+		t.Fatalf("Expected hitting time about %v, but got %v", 9, h)
+	}
+
+	// commute time between ends of the path with m links is 2*m*m
+	line := basic.NewLineGenerator(6).Generate()
+	w = uniform(t, line, &Options{Walks: 5000})
+	ct, err := w.CommuteTime("0", "5")
+	if err != nil {
+		t.Fatalf("CommuteTime failed: %v", err)
+	}
+	if math.Abs(ct-50)/50 > 0.1 {
+		t.Fatalf("Expected commute time about %v, but got %v", 50, ct)
+	}
+
+	// unreachable target
+	g := graph.NewGraph()
+	g.AddNode(graph.NewBasicNode("a"))
+	g.AddNode(graph.NewBasicNode("b"))
+	g.AddNode(graph.NewBasicNode("c"))
+	g.AddLink("a", "b")
+	w = uniform(t, g, &Options{Walks: 3, MaxSteps: 100})
+	if _, err := w.HittingTime("a", "c"); err != ErrNotReached {
+		t.Fatalf("Expected %v, but got %v", ErrNotReached, err)
+	}
+}
+
+func TestSplitBrainCommute(t *testing.T) {
+	// two cliques connected with a single link: commute time between halves
+	// is much larger than within them
+	g := graph.NewGraph()
+	for i := 0; i < 20; i++ {
+		g.AddNode(graph.NewBasicNode(fmt.Sprint(i)))
+	}
+	for half := 0; half < 2; half++ {
+		for i := 0; i < 10; i++ {
+			for j := i + 1; j < 10; j++ {
+				g.AddLink(fmt.Sprint(half*10+i), fmt.Sprint(half*10+j))
+			}
+		}
+	}
+	g.AddLink("0", "10")
+
+	w := uniform(t, g, &Options{Walks: 500})
+	within, err := w.CommuteTime("1", "2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	across, err := w.CommuteTime("1", "11")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if across < 5*within {
+		t.Fatalf("Expected commute time across halves (%v) to be much larger than within (%v)", across, within)
+	}
+}
@@ -2,24 +2,30 @@ package layout
 
 import (
 	"fmt"
-	"math"
 
 	"github.com/divan/graphx/graph"
+	dist "github.com/divan/graphx/graph/distance" // layout has own distance func
 )
 
+// exactWidthLimit sets the maximum number of nodes for which graph width
+// is calculated exactly. For larger graphs sampled estimation is used.
+const exactWidthLimit = 1000
+
+// widthSamples sets number of BFS runs for graph width estimation for large graphs.
+const widthSamples = 16
+
 // NewAuto will init 3D layout and automatically estimate forces and it's paramteres
 // for this particular graph.
-// FIXME: buggy for now
 func NewAuto(g *graph.Graph) *Layout {
 	worldSize := float64(2000) // TODO: this should be synced/communicated to with frontend somehow
 
 	graphWidth := estimateGraphWidth(g)
 
-	optimalEdge := estimateOptimalEdge(worldSize, len(g.Links()))
+	optimalEdge := estimateOptimalEdge(worldSize, graphWidth)
 
 	repForce := -(worldSize / graphWidth / 40)
 	fmt.Println("Optimal edge:", optimalEdge)
-	fmt.Println("Graph width (not real):", graphWidth)
+	fmt.Println("Graph width (diameter):", graphWidth)
 	fmt.Println("Repelling force:", repForce)
 
 	config := Config{
@@ -32,13 +38,25 @@ func NewAuto(g *graph.Graph) *Layout {
 	return New(g, config)
 }
 
-func estimateOptimalEdge(width float64, links int) float64 {
+// estimateOptimalEdge returns spring length, such as the longest shortest
+// path in the graph spans the part of the world.
+func estimateOptimalEdge(worldSize, graphWidth float64) float64 {
 	k := 0.1
-	return k * math.Sqrt((width*width)/float64(links))
+	return k * worldSize / graphWidth
 }
 
+// estimateGraphWidth returns graph width as its diameter (longest shortest path),
+// with links direction ignored, as layout doesn't depend on it.
+// For large graphs it's estimated using sampling.
 func estimateGraphWidth(g *graph.Graph) float64 {
-	// TODO: implement it via "longest short path"
-	// use cube root for now
-	return math.Cbrt(float64(len(g.Nodes())))
+	opts := &dist.Options{IgnoreDirection: true}
+	if g.NumNodes() > exactWidthLimit {
+		opts.Samples = widthSamples
+	}
+
+	diameter := dist.Diameter(g, opts)
+	if diameter < 1 {
+		return 1
+	}
+	return float64(diameter)
 }
@@ -0,0 +1,31 @@
+package layout
+
+import (
+	"testing"
+
+	"github.com/divan/graphx/generation/basic"
+	"github.com/divan/graphx/graph"
+)
+
+func TestEstimateGraphWidth(t *testing.T) {
+	g := basic.NewLineGenerator(20).Generate()
+	if w := estimateGraphWidth(g); w != 19 {
+		t.Fatalf("Expected graph width %v, but got %v", 19.0, w)
+	}
+
+	g = basic.NewGrid2DGenerator(50, 50).Generate() // large enough for sampling
+	if w := estimateGraphWidth(g); w != 98 {
+		t.Fatalf("Expected graph width %v, but got %v", 98.0, w)
+	}
+
+	// directed star with all links pointing to the hub
+	g = graph.NewDirectedGraph()
+	g.AddNode(graph.NewBasicNode("hub"))
+	for _, id := range []string{"a", "b", "c"} {
+		g.AddNode(graph.NewBasicNode(id))
+		g.AddLink(id, "hub")
+	}
+	if w := estimateGraphWidth(g); w != 2 {
+		t.Fatalf("Expected graph width %v, but got %v", 2.0, w)
+	}
+}
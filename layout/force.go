@@ -1,6 +1,10 @@
 package layout
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/divan/graphx/graph"
+)
 
 // ForceVector represents the force vector in 3D space.
 type ForceVector struct {
@@ -16,6 +20,13 @@ type Force interface {
 	Rule() ForceRule
 }
 
+// LinkForce defines force which depends on link properties, like
+// weight. Link-based force rules use it instead of Apply if force
+// implements it.
+type LinkForce interface {
+	ApplyLink(from, to Point, link *graph.Link) *ForceVector
+}
+
 // ZeroForce is a zero force.
 func ZeroForce() *ForceVector { return &ForceVector{} }
 
@@ -13,17 +13,24 @@ type ForceRule func(
 	links []*graph.Link)
 
 // ForEachLink applies force to both ends of each link in the graph, with positive and negative signs respectively.
+// If force implements LinkForce, link itself is passed to the force as well.
 var ForEachLink = func(
 	force Force,
 	objects map[string]*Object,
 	links []*graph.Link) {
+	lf, isLinkForce := force.(LinkForce)
 	for _, link := range links {
 		idFrom := link.From()
 		idTo := link.To()
 
 		from := objects[idFrom]
 		to := objects[idTo]
-		f := force.Apply(from, to)
+		var f *ForceVector
+		if isLinkForce {
+			f = lf.ApplyLink(from, to, link)
+		} else {
+			f = force.Apply(from, to)
+		}
 
 		// Update force vectors
 		objects[idFrom].force.Add(f)
@@ -1,5 +1,7 @@
 package layout
 
+import "github.com/divan/graphx/graph"
+
 // SpringForce calculates spring compression/extension force
 // according to Hooke's law. Implements Force interface.
 type SpringForce struct {
@@ -19,12 +21,27 @@ func NewSpringForce(stiffness, length float64, rule ForceRule) Force {
 
 // Apply calculates the spring force between two nodes. Satisfies Force interface.
 func (s *SpringForce) Apply(from, to Point) *ForceVector {
+	return s.apply(from, to, s.Length)
+}
+
+// ApplyLink calculates the spring force between two nodes, taking link
+// weight into account: spring length is divided by weight, so heavier
+// links pull nodes closer. Satisfies LinkForce interface.
+func (s *SpringForce) ApplyLink(from, to Point, link *graph.Link) *ForceVector {
+	length := s.Length
+	if w := link.Weight(); w > 0 {
+		length /= w
+	}
+	return s.apply(from, to, length)
+}
+
+func (s *SpringForce) apply(from, to Point, length float64) *ForceVector {
 	actualLength := distance(from, to)
 	if actualLength < 1 {
-		actualLength = s.Length / 2
+		actualLength = length / 2
 	}
 
-	stretch := actualLength - s.Length        // deformation distance
+	stretch := actualLength - length          // deformation distance
 	c := s.Stiffness * stretch / actualLength // * float64(from.Mass)
 
 	return &ForceVector{
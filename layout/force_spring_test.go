@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/divan/graphx/generation/basic"
+	"github.com/divan/graphx/graph"
 )
 
 func TestSpring(t *testing.T) {
@@ -48,6 +49,31 @@ func TestSpring(t *testing.T) {
 			t.Logf("Expect diff to be less than %v, got %v", 5.0, math.Abs(d-restLength))
 		}
 	})
+	t.Run("weighted", func(t *testing.T) {
+		restLength := 20.0
+		g := graph.NewGraph()
+		for _, id := range []string{"0", "1", "2", "3"} {
+			g.AddNode(graph.NewBasicNode(id))
+		}
+		g.AddLink("0", "1")
+		g.AddWeightedLink("2", "3", 4)
+		spring := NewSpringForce(0.02, restLength, ForEachLink)
+		l := NewWithForces(g, spring)
+
+		l.objects["0"].SetPosition(0, 0, 0)
+		l.objects["1"].SetPosition(10, 0, 0)
+		l.objects["2"].SetPosition(0, 100, 0)
+		l.objects["3"].SetPosition(10, 100, 0)
+
+		for i := 0; i < 100; i++ {
+			l.UpdatePositions()
+		}
+		light := distance(l.objects["0"], l.objects["1"])
+		heavy := distance(l.objects["2"], l.objects["3"])
+		if heavy >= light {
+			t.Fatalf("Expect heavier link to be shorter, got %v (heavy) vs %v (light)", heavy, light)
+		}
+	})
 }
 
 // checkDistanceX checks left and right distances from their initial positions (x0 and x1) along X axis. they should be equal.
@@ -15,10 +15,19 @@ import (
 // call the system stable
 const stableThreshold = 2.001
 
+// syncEventsBuffer is the size of graph events buffer for layouts
+// created with NewSync.
+const syncEventsBuffer = 1024
+
 // Layout implements Layout interface for force-directed 3D graph.
 type Layout struct {
 	g *graph.Graph
 
+	// set for layouts created with NewSync
+	sg     *graph.SyncGraph
+	events <-chan graph.Event
+	cancel func()
+
 	objects map[string]*Object // node ID as a key
 	keys    []string           // IDs in the order of adding
 	links   []*graph.Link
@@ -57,38 +66,124 @@ func NewWithForces(g *graph.Graph, forces ...Force) *Layout {
 	return l
 }
 
+// NewSync creates a new layout from the given config for the concurrency-safe
+// graph. Layout keeps its own copy of the graph topology, which is updated from
+// graph change events on each UpdatePositions call, so the graph can be modified
+// from other goroutines while layout is being calculated. Close should be called
+// to stop receiving events.
+func NewSync(sg *graph.SyncGraph, config Config) *Layout {
+	l := &Layout{
+		sg:      sg,
+		objects: make(map[string]*Object),
+		config:  config,
+		forces:  forcesFromConfig(config),
+	}
+
+	sg.Read(func(g *graph.Graph) {
+		for _, node := range g.Nodes() {
+			l.addObject(node.ID())
+		}
+		for _, link := range g.Links() {
+			l.links = append(l.links, link.Copy())
+		}
+		l.events, l.cancel = sg.Subscribe(syncEventsBuffer)
+	})
+	l.resetForces()
+
+	return l
+}
+
+// Close stops receiving graph events for layouts created with NewSync.
+// It's no-op for other layouts.
+func (l *Layout) Close() {
+	if l.cancel != nil {
+		l.cancel()
+	}
+}
+
 // initPositions inits layout graph from the original graph data.
 func (l *Layout) initPositions() {
 	for _, node := range l.g.Nodes() {
-		l.addObject(node)
+		l.addObject(node.ID())
 	}
 
 	l.resetForces()
 }
 
-// AddNode handles adding new node to the existing layout.
+// AddNode handles adding new node to the existing layout. For layouts created
+// with NewSync, node is added to the graph and appears in the layout on the next
+// UpdatePositions call.
 func (l *Layout) AddNode(node graph.Node) error {
+	if l.sg != nil {
+		return l.sg.AddNode(node)
+	}
+
 	if _, err := l.g.NodeByID(node.ID()); err == nil {
 		return errors.New("node exists")
 	}
 	l.g.AddNode(node)
 
-	l.addObject(node)
+	l.addObject(node.ID())
 	return nil
 }
 
 // TODO: add link/remove link
 
-func (l *Layout) addObject(node graph.Node) {
+func (l *Layout) addObject(id string) {
 	lastIdx := len(l.keys) // use last item index for calculating pseudo-random positions
 
 	// TODO: handle weight
 
 	x, y, z := randomPosition(lastIdx)
-	object := NewObjectID(x, y, z, node.ID())
+	object := NewObjectID(x, y, z, id)
+
+	l.objects[id] = object
+	l.keys = append(l.keys, id)
+}
 
-	l.objects[node.ID()] = object
-	l.keys = append(l.keys, node.ID())
+func (l *Layout) removeObject(id string) {
+	delete(l.objects, id)
+	for i, key := range l.keys {
+		if key == id {
+			l.keys = append(l.keys[:i], l.keys[i+1:]...)
+			break
+		}
+	}
+}
+
+// applyEvents applies pending graph change events to the layout
+// objects and links.
+func (l *Layout) applyEvents() {
+	for l.events != nil {
+		select {
+		case e, ok := <-l.events:
+			if !ok {
+				l.events = nil
+				return
+			}
+			l.applyEvent(e)
+		default:
+			return
+		}
+	}
+}
+
+func (l *Layout) applyEvent(e graph.Event) {
+	switch e.Type {
+	case graph.NodeAdded:
+		l.addObject(e.NodeID)
+	case graph.NodeRemoved:
+		l.removeObject(e.NodeID)
+	case graph.LinkAdded:
+		l.links = append(l.links, e.Link)
+	case graph.LinkRemoved:
+		for i, link := range l.links {
+			if link.From() == e.From && link.To() == e.To {
+				l.links = append(l.links[:i], l.links[i+1:]...)
+				break
+			}
+		}
+	}
 }
 
 // randomPosition generates x,y,z coordinates pseudo-randomly spread around the
@@ -138,6 +233,7 @@ func (l *Layout) CalculateN(n int) {
 // UpdatePositions recalculates nodes' positions, applying all the forces.
 // It returns average amount of movement generated by this step.
 func (l *Layout) UpdatePositions() float64 {
+	l.applyEvents()
 	l.resetForces()
 
 	l.confMu.RLock()
@@ -209,8 +305,7 @@ func (l *Layout) SetConfig(c Config) {
 func (l *Layout) SetPositions(positions []*Position) {
 	// recalculate objects with new positions
 	//l.resetObjects()
-	for i, node := range l.g.Nodes() {
-		id := node.ID()
+	for i, id := range l.keys {
 		pos := positions[i]
 		obj := l.objects[id]
 		obj.SetPosition(pos.X, pos.Y, pos.Z)
@@ -221,7 +316,8 @@ func (l *Layout) resetObjects() {
 	l.objects = make(map[string]*Object)
 }
 
-// Graph returns original data graph for layout.
+// Graph returns original data graph for layout. It's nil for layouts
+// created with NewSync.
 func (l *Layout) Graph() *graph.Graph {
 	return l.g
 }
@@ -57,6 +57,52 @@ func TestLayoutAdd(t *testing.T) {
 	}
 }
 
+func TestLayoutSync(t *testing.T) {
+	g := graph.NewGraph()
+	g.AddNode(graph.NewBasicNode("node 0"))
+	sg := graph.NewSyncGraph(g)
+	l := NewSync(sg, DefaultConfig)
+	defer l.Close()
+
+	// modify graph while layout is being calculated
+	n := 50
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 1; i < n; i++ {
+			id := fmt.Sprintf("node %d", i)
+			if err := l.AddNode(graph.NewBasicNode(id)); err != nil {
+				t.Errorf("Add node failed: %v", err)
+				return
+			}
+			sg.AddWeightedLink(fmt.Sprintf("node %d", i-1), id, 2)
+		}
+		sg.RemoveNode("node 0")
+	}()
+	for running := true; running; {
+		select {
+		case <-done:
+			running = false
+		default:
+		}
+		l.UpdatePositions()
+	}
+	l.UpdatePositions()
+
+	if err := l.AddNode(graph.NewBasicNode("node 1")); err == nil {
+		t.Fatalf("Expected error for existing node")
+	}
+	if len(l.objects) != n-1 || len(l.keys) != n-1 {
+		t.Fatalf("Expected %d objects, but got %d (%d keys)", n-1, len(l.objects), len(l.keys))
+	}
+	if len(l.links) != n-2 {
+		t.Fatalf("Expected %d links, but got %d", n-2, len(l.links))
+	}
+	if w := l.links[0].Weight(); w != 2 {
+		t.Fatalf("Expected link weight %v, but got %v", 2.0, w)
+	}
+}
+
 func BenchmarkUpdatePositions(b *testing.B) {
 	files, err := readTestData()
 	if err != nil {